@@ -26,7 +26,7 @@ type BulkData struct {
 	Description string `json:"description"`
 
 	// CompressedSize is the compressed size of this file in integer bytes.
-	CompressedSize int `json:"compressed_size"`
+	CompressedSize int `json:"compressed_size,omitempty"`
 
 	// DownloadURI is the URL that hosts this bulk file.
 	DownloadURI string `json:"download_uri"`
@@ -37,6 +37,15 @@ type BulkData struct {
 	// ContentEncoding is the Content-Encoding encoding that will be used
 	// to transmit this file when you download it.
 	ContentEncoding string `json:"content_encoding"`
+
+	extraFields
+}
+
+// MarshalJSON encodes the bulk data item in Scryfall's format, including its
+// UnknownFields.
+func (d BulkData) MarshalJSON() ([]byte, error) {
+	type bulkData BulkData
+	return encodeWithUnknownFields((*bulkData)(&d), "bulk_data", d.UnknownFields())
 }
 
 // ListBulkData returns a list of all bulk data items on Scryfall.
@@ -3,7 +3,6 @@ package scryfall
 import (
 	"context"
 	"fmt"
-	"net/url"
 
 	qs "github.com/google/go-querystring/query"
 )
@@ -274,6 +273,311 @@ const (
 	FrameEffectSpree FrameEffect = "spree"
 )
 
+// Rarity is the rarity of a card. Rarities are ordered like Scryfall orders
+// them: common, uncommon, rare, special, mythic, and bonus.
+type Rarity string
+
+const (
+	// RarityCommon is the common rarity.
+	RarityCommon Rarity = "common"
+
+	// RarityUncommon is the uncommon rarity.
+	RarityUncommon Rarity = "uncommon"
+
+	// RarityRare is the rare rarity.
+	RarityRare Rarity = "rare"
+
+	// RaritySpecial is the rarity of special cards, such as Time Spiral's
+	// timeshifted cards.
+	RaritySpecial Rarity = "special"
+
+	// RarityMythic is the mythic rare rarity.
+	RarityMythic Rarity = "mythic"
+
+	// RarityBonus is the rarity of bonus cards, such as Power Nine cards in
+	// Vintage Masters.
+	RarityBonus Rarity = "bonus"
+)
+
+// rarityRanks holds the position of each known rarity in Scryfall's order,
+// used by Compare.
+var rarityRanks = map[Rarity]int{
+	RarityCommon:   0,
+	RarityUncommon: 1,
+	RarityRare:     2,
+	RaritySpecial:  3,
+	RarityMythic:   4,
+	RarityBonus:    5,
+}
+
+// Known reports whether the rarity is one of the Rarity constants. Rarities
+// added by Scryfall after this version of the package still decode, keeping
+// their value, but aren't known.
+func (r Rarity) Known() bool {
+	return knownRarities[string(r)]
+}
+
+// Compare returns -1 if r is lower than other, 0 if they're the same, and +1
+// if r is higher than other. Unknown rarities are higher than all known
+// rarities and compared by value between themselves.
+func (r Rarity) Compare(other Rarity) int {
+	rank, ok := rarityRanks[r]
+	otherRank, otherOK := rarityRanks[other]
+	switch {
+	case !ok && !otherOK:
+		if r < other {
+			return -1
+		}
+		if r > other {
+			return 1
+		}
+		return 0
+	case !ok:
+		return 1
+	case !otherOK:
+		return -1
+	case rank < otherRank:
+		return -1
+	case rank > otherRank:
+		return 1
+	}
+	return 0
+}
+
+// Less reports whether r is lower than other, see Compare.
+func (r Rarity) Less(other Rarity) bool {
+	return r.Compare(other) < 0
+}
+
+// BorderColor is the color of a card's border.
+type BorderColor string
+
+const (
+	// BorderColorBlack is a black border.
+	BorderColorBlack BorderColor = "black"
+
+	// BorderColorWhite is a white border.
+	BorderColorWhite BorderColor = "white"
+
+	// BorderColorBorderless is a card without a border.
+	BorderColorBorderless BorderColor = "borderless"
+
+	// BorderColorSilver is the silver border of cards which aren't legal in
+	// tournaments, such as Un-set cards.
+	BorderColorSilver BorderColor = "silver"
+
+	// BorderColorGold is the gold border of cards which aren't legal in
+	// tournaments, such as World Championship Decks cards.
+	BorderColorGold BorderColor = "gold"
+
+	// BorderColorYellow is the yellow border of some Alchemy cards.
+	BorderColorYellow BorderColor = "yellow"
+)
+
+// Known reports whether the border color is one of the BorderColor
+// constants.
+func (b BorderColor) Known() bool {
+	return knownBorderColors[string(b)]
+}
+
+// SecurityStamp is the security stamp printed on a card.
+type SecurityStamp string
+
+const (
+	// SecurityStampOval is the oval security stamp.
+	SecurityStampOval SecurityStamp = "oval"
+
+	// SecurityStampTriangle is the triangle security stamp of Universes
+	// Beyond cards.
+	SecurityStampTriangle SecurityStamp = "triangle"
+
+	// SecurityStampAcorn is the acorn security stamp of cards which aren't
+	// legal in tournaments.
+	SecurityStampAcorn SecurityStamp = "acorn"
+
+	// SecurityStampCircle is the circle security stamp.
+	SecurityStampCircle SecurityStamp = "circle"
+
+	// SecurityStampArena is the Arena security stamp.
+	SecurityStampArena SecurityStamp = "arena"
+
+	// SecurityStampHeart is the heart security stamp of My Little Pony
+	// cards.
+	SecurityStampHeart SecurityStamp = "heart"
+)
+
+// Known reports whether the security stamp is one of the SecurityStamp
+// constants.
+func (s SecurityStamp) Known() bool {
+	return knownSecurityStamps[string(s)]
+}
+
+// PromoType is a kind of promotional printing or treatment of a card.
+type PromoType string
+
+const (
+	// PromoTypeAlchemy is an Alchemy rebalanced or digital card.
+	PromoTypeAlchemy PromoType = "alchemy"
+
+	// PromoTypeArenaLeague is an Arena League promo.
+	PromoTypeArenaLeague PromoType = "arenaleague"
+
+	// PromoTypeBoosterFun is a Booster Fun treatment.
+	PromoTypeBoosterFun PromoType = "boosterfun"
+
+	// PromoTypeBoxTopper is a box topper.
+	PromoTypeBoxTopper PromoType = "boxtopper"
+
+	// PromoTypeBrawlDeck is a Brawl deck promo.
+	PromoTypeBrawlDeck PromoType = "brawldeck"
+
+	// PromoTypeBundle is a bundle promo.
+	PromoTypeBundle PromoType = "bundle"
+
+	// PromoTypeBuyABox is a buy-a-box promo.
+	PromoTypeBuyABox PromoType = "buyabox"
+
+	// PromoTypeConcept is a concept praetor.
+	PromoTypeConcept PromoType = "concept"
+
+	// PromoTypeConfettiFoil is a confetti foil.
+	PromoTypeConfettiFoil PromoType = "confettifoil"
+
+	// PromoTypeConvention is a convention promo.
+	PromoTypeConvention PromoType = "convention"
+
+	// PromoTypeDatestamped is a prerelease promo stamped with a date.
+	PromoTypeDatestamped PromoType = "datestamped"
+
+	// PromoTypeDoubleRainbow is a double rainbow foil.
+	PromoTypeDoubleRainbow PromoType = "doublerainbow"
+
+	// PromoTypeDraftWeekend is a draft weekend promo.
+	PromoTypeDraftWeekend PromoType = "draftweekend"
+
+	// PromoTypeDuels is a Duels of the Planeswalkers promo.
+	PromoTypeDuels PromoType = "duels"
+
+	// PromoTypeEmbossed is an embossed foil.
+	PromoTypeEmbossed PromoType = "embossed"
+
+	// PromoTypeEvent is an event promo.
+	PromoTypeEvent PromoType = "event"
+
+	// PromoTypeFNM is a Friday Night Magic promo.
+	PromoTypeFNM PromoType = "fnm"
+
+	// PromoTypeGalaxyFoil is a galaxy foil.
+	PromoTypeGalaxyFoil PromoType = "galaxyfoil"
+
+	// PromoTypeGameDay is a game day promo.
+	PromoTypeGameDay PromoType = "gameday"
+
+	// PromoTypeGateway is a Gateway promo.
+	PromoTypeGateway PromoType = "gateway"
+
+	// PromoTypeGiftBox is a gift box promo.
+	PromoTypeGiftBox PromoType = "giftbox"
+
+	// PromoTypeGilded is a gilded foil.
+	PromoTypeGilded PromoType = "gilded"
+
+	// PromoTypeHaloFoil is a halo foil.
+	PromoTypeHaloFoil PromoType = "halofoil"
+
+	// PromoTypeInStore is an in-store promo.
+	PromoTypeInStore PromoType = "instore"
+
+	// PromoTypeIntroPack is an intro pack promo.
+	PromoTypeIntroPack PromoType = "intropack"
+
+	// PromoTypeJudgeGift is a judge gift promo.
+	PromoTypeJudgeGift PromoType = "judgegift"
+
+	// PromoTypeLeague is a league promo.
+	PromoTypeLeague PromoType = "league"
+
+	// PromoTypeNeonInk is a neon ink foil.
+	PromoTypeNeonInk PromoType = "neonink"
+
+	// PromoTypeOilSlick is an oil slick raised foil.
+	PromoTypeOilSlick PromoType = "oilslick"
+
+	// PromoTypeOpenHouse is an open house promo.
+	PromoTypeOpenHouse PromoType = "openhouse"
+
+	// PromoTypePlaneswalkerDeck is a planeswalker deck promo.
+	PromoTypePlaneswalkerDeck PromoType = "planeswalkerdeck"
+
+	// PromoTypePlayerRewards is a player rewards promo.
+	PromoTypePlayerRewards PromoType = "playerrewards"
+
+	// PromoTypePlayPromo is a play promo.
+	PromoTypePlayPromo PromoType = "playpromo"
+
+	// PromoTypePremiereShop is a premiere shop promo.
+	PromoTypePremiereShop PromoType = "premiereshop"
+
+	// PromoTypePrerelease is a prerelease promo.
+	PromoTypePrerelease PromoType = "prerelease"
+
+	// PromoTypePromoPack is a promo pack promo.
+	PromoTypePromoPack PromoType = "promopack"
+
+	// PromoTypeRainbowFoil is a rainbow foil.
+	PromoTypeRainbowFoil PromoType = "rainbowfoil"
+
+	// PromoTypeRaisedFoil is a raised foil.
+	PromoTypeRaisedFoil PromoType = "raisedfoil"
+
+	// PromoTypeRebalanced is an Alchemy rebalanced card.
+	PromoTypeRebalanced PromoType = "rebalanced"
+
+	// PromoTypeRelease is a release promo.
+	PromoTypeRelease PromoType = "release"
+
+	// PromoTypeRippleFoil is a ripple foil.
+	PromoTypeRippleFoil PromoType = "ripplefoil"
+
+	// PromoTypeSerialized is a serialized card.
+	PromoTypeSerialized PromoType = "serialized"
+
+	// PromoTypeSetPromo is a set promo.
+	PromoTypeSetPromo PromoType = "setpromo"
+
+	// PromoTypeStamped is a stamped card.
+	PromoTypeStamped PromoType = "stamped"
+
+	// PromoTypeStarterDeck is a starter deck promo.
+	PromoTypeStarterDeck PromoType = "starterdeck"
+
+	// PromoTypeStepAndCompleat is a step-and-compleat foil.
+	PromoTypeStepAndCompleat PromoType = "stepandcompleat"
+
+	// PromoTypeSurgeFoil is a surge foil.
+	PromoTypeSurgeFoil PromoType = "surgefoil"
+
+	// PromoTypeTextured is a textured foil.
+	PromoTypeTextured PromoType = "textured"
+
+	// PromoTypeThemePack is a theme pack promo.
+	PromoTypeThemePack PromoType = "themepack"
+
+	// PromoTypeThick is a thick display card.
+	PromoTypeThick PromoType = "thick"
+
+	// PromoTypeTourney is a tournament promo.
+	PromoTypeTourney PromoType = "tourney"
+
+	// PromoTypeWizardsPlayNetwork is a Wizards Play Network promo.
+	PromoTypeWizardsPlayNetwork PromoType = "wizardsplaynetwork"
+)
+
+// Known reports whether the promo type is one of the PromoType constants.
+func (p PromoType) Known() bool {
+	return knownPromoTypes[string(p)]
+}
+
 type Preview struct {
 	// PreviewedAt is the date this card was previewed.
 	PreviewedAt Date `json:"previewed_at"`
@@ -387,7 +691,7 @@ type Card struct {
 	TCGPlayerEtchedID *int `json:"tcgplayer_etched_id,omitempty"`
 
 	// CardMarketID is this card's ID on Cardmarket's API, also known as the idProduct.
-	CardMarketID *int `json:"Integer,omitempty"`
+	CardMarketID *int `json:"cardmarket_id,omitempty"`
 
 	// PrintsSearchURI is a link to where you can begin paginating all
 	// re/prints for this card on Scryfall's API.
@@ -403,7 +707,7 @@ type Card struct {
 	// PrintedName is the printed name of this card.
 	// This will only be set if the card is not in English.
 	// If this card has multiple faces, this field will not be set.
-	PrintedName *string `json:"printed_name"`
+	PrintedName *string `json:"printed_name,omitempty"`
 
 	// Layout is a computer-readable designation for this card's
 	// layout. See the layout article.
@@ -418,14 +722,14 @@ type Card struct {
 
 	// PrintedTypeLine is the type line of this card, as writted on the card.
 	// This will only be set if the card is not in English.
-	PrintedTypeLine *string `json:"printed_type_line"`
+	PrintedTypeLine *string `json:"printed_type_line,omitempty"`
 
 	// OracleText is the Oracle text for this card, if any.
 	OracleText string `json:"oracle_text"`
 
 	// PrintedText is the printed text for this card, if any.
 	// This will only be set if the card is not in English.
-	PrintedText *string `json:"printed_text"`
+	PrintedText *string `json:"printed_text,omitempty"`
 
 	// ManaCost is the mana cost for this card. This value will be any
 	// empty string "" if the cost is absent. Remember that per the game
@@ -434,43 +738,43 @@ type Card struct {
 
 	// Power is this card's power, if any. Note that some cards have powers
 	// that are not numeric, such as *.
-	Power *string `json:"power"`
+	Power *string `json:"power,omitempty"`
 
 	// Toughness is this card's toughness, if any. Note that some cards
 	// have toughnesses that are not numeric, such as *.
-	Toughness *string `json:"toughness"`
+	Toughness *string `json:"toughness,omitempty"`
 
 	// Loyalty is this loyalty if any. Note that some cards have loyalties
 	// that are not numeric, such as X.
-	Loyalty *string `json:"loyalty"`
+	Loyalty *string `json:"loyalty,omitempty"`
 
 	// Defense is the face's defense, if any.
-	Defense *string `json:"defense"`
+	Defense *string `json:"defense,omitempty"`
 
 	// LifeModifier is this card's life modifier, if it is Vanguard
 	// card. This value will contain a delta, such as +2.
-	LifeModifier *string `json:"life_modifier"`
+	LifeModifier *string `json:"life_modifier,omitempty"`
 
 	// HandModifier is this card's hand modifier, if it is Vanguard
 	// card. This value will contain a delta, such as -1.
-	HandModifier *string `json:"hand_modifier"`
+	HandModifier *string `json:"hand_modifier,omitempty"`
 
 	// Colors is this card's colors.
 	Colors []Color `json:"colors"`
 
 	// ColorIndicator is the colors in this card's color indicator, if
 	// any. A nil value for this field indicates the card does not have one.
-	ColorIndicator []Color `json:"color_indicator"`
+	ColorIndicator []Color `json:"color_indicator,omitempty"`
 
 	// ColorIdentity is this card's color identity.
 	ColorIdentity []Color `json:"color_identity"`
 
 	// AllParts is a list of closely related cards, if any.
-	AllParts []RelatedCard `json:"all_parts"`
+	AllParts []RelatedCard `json:"all_parts,omitempty"`
 
 	// CardFaces is An array of card Face objects, if this card is
 	// multifaced.
-	CardFaces []CardFace `json:"card_faces"`
+	CardFaces []CardFace `json:"card_faces,omitempty"`
 
 	// Legalities is an object describing the legality of this card.
 	Legalities Legalities `json:"legalities"`
@@ -498,7 +802,7 @@ type Card struct {
 
 	// EDHRECRank is this card's overall rank/popularity on EDHREC. Not all
 	// cards are ranked.
-	EDHRECRank *int `json:"edhrec_rank"`
+	EDHRECRank *int `json:"edhrec_rank,omitempty"`
 
 	// Set is this card's set code.
 	Set string `json:"set"`
@@ -521,7 +825,7 @@ type Card struct {
 	ScryfallSetURI string `json:"scryfall_set_uri"`
 
 	// ImageURIs is an object listing available imagery for this card.
-	ImageURIs *ImageURIs `json:"image_uris"`
+	ImageURIs *ImageURIs `json:"image_uris,omitempty"`
 
 	// Prices contains daily price information for this card, including
 	// usd, usd_foil, eur, and tix prices.
@@ -536,49 +840,52 @@ type Card struct {
 	// Digital is true if this is a digital card on Magic Online.
 	Digital bool `json:"digital"`
 
-	// Rarity is this card's rarity. One of common, uncommon, rare, or
-	// mythic.
-	Rarity string `json:"rarity"`
+	// Rarity is this card's rarity. One of common, uncommon, rare,
+	// special, mythic, or bonus.
+	Rarity Rarity `json:"rarity"`
 
 	// FlavorText is the flavor text, if any.
-	FlavorText *string `json:"flavor_text"`
+	FlavorText *string `json:"flavor_text,omitempty"`
 
 	// Artist is the name of the illustrator of this card. Newly spoiled
 	// cards may not have this field yet.
-	Artist *string `json:"artist"`
+	Artist *string `json:"artist,omitempty"`
 
 	// IllustrationID is a unique identifier for the card artwork that
 	// remains consistent across reprints. Newly spoiled cards may not have
 	// this field yet.
-	IllustrationID *string `json:"illustration_id"`
+	IllustrationID *string `json:"illustration_id,omitempty"`
 
 	// Frame is this card's frame layout.
 	Frame Frame `json:"frame"`
 
 	// FrameEffects is this card's frame effects, if any.
-	FrameEffects []FrameEffect `json:"frame_effects"`
+	FrameEffects []FrameEffect `json:"frame_effects,omitempty"`
 
 	// FullArt is true if this card's artwork is larger than normal.
 	FullArt bool `json:"full_art"`
 
 	// Watermark is this card's watermark, if any.
-	Watermark *string `json:"watermark"`
+	Watermark *string `json:"watermark,omitempty"`
 
 	// Preview contains information about who previewed/spoiled this card.
 	Preview Preview `json:"preview"`
 
 	// PromoTypes is an array of promo types for this card, if any.
-	PromoTypes []string `json:"promo_types,omitempty"`
+	PromoTypes []PromoType `json:"promo_types,omitempty"`
 
 	// BorderColor is this card's border color: black, borderless, gold,
-	// silver, or white.
-	BorderColor string `json:"border_color"`
+	// silver, white, or yellow.
+	BorderColor BorderColor `json:"border_color"`
+
+	// SecurityStamp is the security stamp on this card, if any.
+	SecurityStamp *SecurityStamp `json:"security_stamp,omitempty"`
 
 	// StorySpotlightNumber is this card's story spotlight number, if any.
-	StorySpotlightNumber *int `json:"story_spotlight_number"`
+	StorySpotlightNumber *int `json:"story_spotlight_number,omitempty"`
 
 	// StorySpotlightURI is a URL to this cards's story article, if any.
-	StorySpotlightURI *string `json:"story_spotlight_uri"`
+	StorySpotlightURI *string `json:"story_spotlight_uri,omitempty"`
 
 	// RelatedURIs contains links related to a card.
 	RelatedURIs RelatedURIs `json:"related_uris"`
@@ -593,7 +900,7 @@ type Card struct {
 	Keywords []string `json:"keywords"`
 
 	// ProducedMana are colors of mana that this card could produce.
-	ProducedMana []Color `json:"produced_mana"`
+	ProducedMana []Color `json:"produced_mana,omitempty"`
 
 	// Booster is whether this card is found in boosters.
 	Booster bool `json:"booster"`
@@ -619,6 +926,46 @@ type Card struct {
 	//
 	// [Commander Game Changer list]: https://mtg.wiki/page/Game_Changers
 	GameChanger *bool `json:"game_changer,omitempty"`
+
+	extraFields
+}
+
+// MarshalJSON encodes the card in Scryfall's format, including its
+// UnknownFields.
+func (c Card) MarshalJSON() ([]byte, error) {
+	type card Card
+	// Like Scryfall, leave out the colors if they're absent rather than
+	// empty, the Oracle text and mana cost of multifaced cards if they're
+	// only set on the faces, and the preview and links if there aren't any.
+	v := struct {
+		card
+		Colors       *[]Color      `json:"colors,omitempty"`
+		OracleText   *string       `json:"oracle_text,omitempty"`
+		ManaCost     *string       `json:"mana_cost,omitempty"`
+		Preview      *Preview      `json:"preview,omitempty"`
+		RelatedURIs  *RelatedURIs  `json:"related_uris,omitempty"`
+		PurchaseURIs *PurchaseURIs `json:"purchase_uris,omitempty"`
+	}{card: card(c)}
+	if c.Colors != nil {
+		v.Colors = &c.Colors
+	}
+	if len(c.CardFaces) == 0 || len(c.OracleText) != 0 {
+		v.OracleText = &c.OracleText
+	}
+	if len(c.CardFaces) == 0 || len(c.ManaCost) != 0 {
+		v.ManaCost = &c.ManaCost
+	}
+	if c.Preview != (Preview{}) {
+		v.Preview = &c.Preview
+	}
+	if c.RelatedURIs != (RelatedURIs{}) {
+		v.RelatedURIs = &c.RelatedURIs
+	}
+	if c.PurchaseURIs != (PurchaseURIs{}) {
+		v.PurchaseURIs = &c.PurchaseURIs
+	}
+
+	return encodeWithUnknownFields(&v, "card", c.UnknownFields())
 }
 
 // RelatedCard is a card that is closely related to another card (because it
@@ -640,6 +987,15 @@ type RelatedCard struct {
 	// URI is a URI where you can retrieve a full object describing this
 	// card on Scryfall's API.
 	URI string `json:"uri"`
+
+	extraFields
+}
+
+// MarshalJSON encodes the related card in Scryfall's format, including its
+// UnknownFields.
+func (r RelatedCard) MarshalJSON() ([]byte, error) {
+	type relatedCard RelatedCard
+	return encodeWithUnknownFields((*relatedCard)(&r), "related_card", r.UnknownFields())
 }
 
 // CardFace is a face of a multifaced card.
@@ -649,21 +1005,21 @@ type CardFace struct {
 
 	// PrintedName is the printed name of this particular face.
 	// This will only be set if the card is not in English.
-	PrintedName *string `json:"printed_name"`
+	PrintedName *string `json:"printed_name,omitempty"`
 
 	// TypeLine is the type line of this particular face.
 	TypeLine string `json:"type_line"`
 
 	// PrintedTypeLine is the printed type line of this particular face.
 	// This will only be set if the card is not in English.
-	PrintedTypeLine *string `json:"printed_type_line"`
+	PrintedTypeLine *string `json:"printed_type_line,omitempty"`
 
 	// OracleText is the Oracle text for this face, if any.
-	OracleText *string `json:"oracle_text"`
+	OracleText *string `json:"oracle_text,omitempty"`
 
 	// PrintedText is the printed text for this face, if any.
 	// This will only be set if the card is not in English.
-	PrintedText *string `json:"printed_text"`
+	PrintedText *string `json:"printed_text,omitempty"`
 
 	// ManaCost is the mana cost for this face. This value will be any
 	// empty string "" if the cost is absent. Remember that per the game
@@ -674,20 +1030,20 @@ type CardFace struct {
 	Colors []Color `json:"colors"`
 
 	// ColorIndicator is the colors in this face's color indicator, if any.
-	ColorIndicator []Color `json:"color_indicator"`
+	ColorIndicator []Color `json:"color_indicator,omitempty"`
 
 	// Power is this face's power, if any. Note that some cards have powers
 	// that are not numeric, such as *.
-	Power *string `json:"power"`
+	Power *string `json:"power,omitempty"`
 
 	// Toughness is this face's toughness, if any.
-	Toughness *string `json:"toughness"`
+	Toughness *string `json:"toughness,omitempty"`
 
 	// Layout is the layout of this card face, if the card is reversible.
-	Layout *Layout `json:"layout"`
+	Layout *Layout `json:"layout,omitempty"`
 
 	// Loyalty is this face's loyalty, if any.
-	Loyalty *string `json:"loyalty"`
+	Loyalty *string `json:"loyalty,omitempty"`
 
 	// OracleID is the Oracle ID of this particular face, if the card is
 	// reversible.
@@ -695,20 +1051,43 @@ type CardFace struct {
 
 	// Defense is the face's defense, if the game defines colors for the
 	// individual face of this card.
-	Defense *string `json:"defense"`
+	Defense *string `json:"defense,omitempty"`
 
 	// FlavorText is the flavor text printed on this face, if any.
-	FlavorText *string `json:"flavor_text"`
+	FlavorText *string `json:"flavor_text,omitempty"`
 
 	// IllustrationID is a unique identifier for the card face artwork that
 	// remains consistent across reprints. Newly spoiled cards may not have
 	// this field yet.
-	IllustrationID *string `json:"illustration_id"`
+	IllustrationID *string `json:"illustration_id,omitempty"`
 
 	// ImageURIs is an object providing URIs to imagery for this face, if
 	// this is a double-sided card. If this card is not double-sided, then the
 	// image_uris property will be part of the parent object instead.
 	ImageURIs ImageURIs `json:"image_uris"`
+
+	extraFields
+}
+
+// MarshalJSON encodes the card face in Scryfall's format, including its
+// UnknownFields.
+func (f CardFace) MarshalJSON() ([]byte, error) {
+	type cardFace CardFace
+	// Like Scryfall, leave out the colors if they're absent rather than
+	// empty and the image URIs if the face doesn't have its own images.
+	v := struct {
+		cardFace
+		Colors    *[]Color   `json:"colors,omitempty"`
+		ImageURIs *ImageURIs `json:"image_uris,omitempty"`
+	}{cardFace: cardFace(f)}
+	if f.Colors != nil {
+		v.Colors = &f.Colors
+	}
+	if f.ImageURIs != (ImageURIs{}) {
+		v.ImageURIs = &f.ImageURIs
+	}
+
+	return encodeWithUnknownFields(&v, "card_face", f.UnknownFields())
 }
 
 // ImageURIs contains links to the different image sizes and crops for a given
@@ -759,6 +1138,38 @@ type Prices struct {
 
 	// Tix is the price of the card in MTGO event tickets.
 	Tix string `json:"tix"`
+
+	extraFields
+}
+
+// MarshalJSON encodes the prices in Scryfall's format, where missing prices
+// are null, including their UnknownFields.
+func (p Prices) MarshalJSON() ([]byte, error) {
+	v := struct {
+		USD       *string `json:"usd"`
+		USDFoil   *string `json:"usd_foil"`
+		USDEtched *string `json:"usd_etched"`
+		EUR       *string `json:"eur"`
+		EURFoil   *string `json:"eur_foil"`
+		Tix       *string `json:"tix"`
+	}{
+		USD:       nullablePrice(p.USD),
+		USDFoil:   nullablePrice(p.USDFoil),
+		USDEtched: nullablePrice(p.USDEtched),
+		EUR:       nullablePrice(p.EUR),
+		EURFoil:   nullablePrice(p.EURFoil),
+		Tix:       nullablePrice(p.Tix),
+	}
+
+	return encodeWithUnknownFields(&v, "", p.UnknownFields())
+}
+
+// nullablePrice returns a pointer to the price, or nil if it's missing.
+func nullablePrice(price string) *string {
+	if len(price) == 0 {
+		return nil
+	}
+	return &price
 }
 
 // Legalities describes the legality of a card across formats.
@@ -785,21 +1196,48 @@ type Legalities struct {
 	PreModern         Legality `json:"premodern"`
 	PreDH             Legality `json:"predh"`
 	TinyLeadersReborn Legality `json:"tlr"`
+
+	extraFields
+}
+
+// MarshalJSON encodes the legalities in Scryfall's format, including their
+// UnknownFields.
+func (l Legalities) MarshalJSON() ([]byte, error) {
+	type legalities Legalities
+	return encodeWithUnknownFields((*legalities)(&l), "", l.UnknownFields())
 }
 
 // RelatedURIs contains links related to a card.
 type RelatedURIs struct {
-	Gatherer       string `json:"gatherer"`
-	TCGPlayerDecks string `json:"tcgplayer_decks"`
-	EDHREC         string `json:"edhrec"`
-	MTGTop8        string `json:"mtgtop8"`
+	Gatherer       string `json:"gatherer,omitempty"`
+	TCGPlayerDecks string `json:"tcgplayer_decks,omitempty"`
+	EDHREC         string `json:"edhrec,omitempty"`
+	MTGTop8        string `json:"mtgtop8,omitempty"`
+
+	extraFields
+}
+
+// MarshalJSON encodes the related URIs in Scryfall's format, including their
+// UnknownFields.
+func (u RelatedURIs) MarshalJSON() ([]byte, error) {
+	type relatedURIs RelatedURIs
+	return encodeWithUnknownFields((*relatedURIs)(&u), "", u.UnknownFields())
 }
 
 // PurchaseURIs contains links to the card on online card stores.
 type PurchaseURIs struct {
-	TCGPlayer   string `json:"tcgplayer"`
-	CardMarket  string `json:"cardmarket"`
-	CardHoarder string `json:"cardhoarder"`
+	TCGPlayer   string `json:"tcgplayer,omitempty"`
+	CardMarket  string `json:"cardmarket,omitempty"`
+	CardHoarder string `json:"cardhoarder,omitempty"`
+
+	extraFields
+}
+
+// MarshalJSON encodes the purchase URIs in Scryfall's format, including their
+// UnknownFields.
+func (u PurchaseURIs) MarshalJSON() ([]byte, error) {
+	type purchaseURIs PurchaseURIs
+	return encodeWithUnknownFields((*purchaseURIs)(&u), "", u.UnknownFields())
 }
 
 // UniqueMode specifies whether Scryfall should remove duplicates from search
@@ -931,7 +1369,7 @@ type CardListResponse struct {
 
 	// NextPage contains a full API URI to next page if there is a page
 	// beyond the current page.
-	NextPage *string `json:"next_page"`
+	NextPage *string `json:"next_page,omitempty"`
 
 	// TotalCards contains the total number of cards found across all
 	// pages.
@@ -942,7 +1380,13 @@ type CardListResponse struct {
 	// discovered with your input. In general, they indicate that the List
 	// will not contain the all of the information you requested. You should
 	// fix the warnings and re-submit your request.
-	Warnings []string `json:"warnings"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// MarshalJSON encodes the card list in Scryfall's format.
+func (r CardListResponse) MarshalJSON() ([]byte, error) {
+	type cardListResponse CardListResponse
+	return encodeWithUnknownFields((*cardListResponse)(&r), "list", nil)
 }
 
 // SearchCards returns a list cards found using a full text search. The query
@@ -966,6 +1410,42 @@ func (c *Client) SearchCards(ctx context.Context, query string, opts SearchCards
 	return result, nil
 }
 
+// searchAllCards performs a full text search and returns the cards from every
+// page of the results.
+func (c *Client) searchAllCards(ctx context.Context, query string, opts SearchCardsOptions) ([]Card, error) {
+	values, err := qs.Values(opts)
+	if err != nil {
+		return nil, err
+	}
+	values.Set("q", query)
+	cardsURL := fmt.Sprintf("cards/search?%s", values.Encode())
+
+	return c.listAllCards(ctx, cardsURL)
+}
+
+// listAllCards retrieves every card in a paginated card list by following
+// each page's NextPage link until there are no more pages. Like the other
+// links in Scryfall objects, NextPage must belong to the configured base URL.
+func (c *Client) listAllCards(ctx context.Context, url string) ([]Card, error) {
+	cards := []Card{}
+	for {
+		result := CardListResponse{}
+		err := c.get(ctx, url, &result)
+		if err != nil {
+			return nil, err
+		}
+		cards = append(cards, result.Cards...)
+
+		if !result.HasMore || result.NextPage == nil {
+			return cards, nil
+		}
+		url, err = c.apiURI(*result.NextPage)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
 func (c *Client) getCard(ctx context.Context, url string) (Card, error) {
 	card := Card{}
 	err := c.get(ctx, url, &card)
@@ -1003,30 +1483,39 @@ type GetCardByNameOptions struct {
 // optional (you can drop apostrophes and periods etc). For example: fIReBALL is
 // the same as Fireball and smugglers copter is the same as Smuggler's Copter
 func (c *Client) GetCardByName(ctx context.Context, name string, exact bool, opts GetCardByNameOptions) (Card, error) {
-	values, err := qs.Values(opts)
+	values, err := cardByNameValues(name, exact, opts)
 	if err != nil {
 		return Card{}, err
 	}
 
-	if exact {
-		values.Set("exact", name)
-	} else {
-		values.Set("fuzzy", name)
-	}
-
 	cardURL := fmt.Sprintf("cards/named?%s", values.Encode())
 	return c.getCard(ctx, cardURL)
 }
 
+// AutocompleteCardOptions holds the options used to autocomplete card names.
+type AutocompleteCardOptions struct {
+	// IncludeExtras determines whether extra cards (tokens, planes, etc.)
+	// should be included.
+	IncludeExtras bool `url:"include_extras,omitempty"`
+}
+
 // AutocompleteCard returns a slice containing up to 20 full English card names
 // that could be autocompletions of the given string parameter.
 func (c *Client) AutocompleteCard(ctx context.Context, s string) ([]string, error) {
-	values := url.Values{}
+	return c.AutocompleteCardWithOptions(ctx, s, AutocompleteCardOptions{})
+}
+
+// AutocompleteCardWithOptions is like AutocompleteCard, with options.
+func (c *Client) AutocompleteCardWithOptions(ctx context.Context, s string, opts AutocompleteCardOptions) ([]string, error) {
+	values, err := qs.Values(opts)
+	if err != nil {
+		return nil, err
+	}
 	values.Set("q", s)
 	autocompleteCardURL := fmt.Sprintf("cards/autocomplete?%s", values.Encode())
 
 	catalog := Catalog{}
-	err := c.get(ctx, autocompleteCardURL, &catalog)
+	err = c.get(ctx, autocompleteCardURL, &catalog)
 	if err != nil {
 		return nil, err
 	}
@@ -1034,9 +1523,32 @@ func (c *Client) AutocompleteCard(ctx context.Context, s string) ([]string, erro
 	return catalog.Data, nil
 }
 
+// GetRandomCardOptions holds the options used to get a random card.
+type GetRandomCardOptions struct {
+	// Query limits the random card to cards matching the full text search
+	// query. See the search reference docs for more information on the full
+	// text search query format: https://scryfall.com/docs/reference.
+	Query string `url:"q,omitempty"`
+}
+
 // GetRandomCard returns a random card.
 func (c *Client) GetRandomCard(ctx context.Context) (Card, error) {
-	return c.getCard(ctx, "cards/random")
+	return c.GetRandomCardWithOptions(ctx, GetRandomCardOptions{})
+}
+
+// GetRandomCardWithOptions returns a random card, optionally limited to cards
+// matching a search query.
+func (c *Client) GetRandomCardWithOptions(ctx context.Context, opts GetRandomCardOptions) (Card, error) {
+	values, err := qs.Values(opts)
+	if err != nil {
+		return Card{}, err
+	}
+
+	cardURL := "cards/random"
+	if len(values) != 0 {
+		cardURL = fmt.Sprintf("cards/random?%s", values.Encode())
+	}
+	return c.getCard(ctx, cardURL)
 }
 
 // CardIdentifier identifies a card.
@@ -1092,6 +1604,12 @@ type GetCardsByIdentifiersResponse struct {
 	Data []Card `json:"data"`
 }
 
+// MarshalJSON encodes the card list in Scryfall's format.
+func (r GetCardsByIdentifiersResponse) MarshalJSON() ([]byte, error) {
+	type getCardsByIdentifiersResponse GetCardsByIdentifiersResponse
+	return encodeWithUnknownFields((*getCardsByIdentifiersResponse)(&r), "list", nil)
+}
+
 // GetCardsByIdentifiers accepts a list of card identifiers and returns the
 // collection of requested cards. A maximum of 75 card references may be submitted
 // per request.
@@ -1108,6 +1626,34 @@ func (c *Client) GetCardsByIdentifiers(ctx context.Context, identifiers []CardId
 	return getCardsByIdentifiersResponse, nil
 }
 
+// maxCardIdentifiers is the maximum number of card identifiers that can be
+// submitted in a single GetCardsByIdentifiers request.
+const maxCardIdentifiers = 75
+
+// getAllCardsByIdentifiers is like GetCardsByIdentifiers but splits the
+// identifiers into as many requests as needed and merges the results.
+func (c *Client) getAllCardsByIdentifiers(ctx context.Context, identifiers []CardIdentifier) (GetCardsByIdentifiersResponse, error) {
+	merged := GetCardsByIdentifiersResponse{
+		NotFound: []CardIdentifier{},
+		Data:     []Card{},
+	}
+	for start := 0; start < len(identifiers); start += maxCardIdentifiers {
+		end := start + maxCardIdentifiers
+		if end > len(identifiers) {
+			end = len(identifiers)
+		}
+
+		response, err := c.GetCardsByIdentifiers(ctx, identifiers[start:end])
+		if err != nil {
+			return GetCardsByIdentifiersResponse{}, err
+		}
+		merged.NotFound = append(merged.NotFound, response.NotFound...)
+		merged.Data = append(merged.Data, response.Data...)
+	}
+
+	return merged, nil
+}
+
 // GetCardBySetCodeAndCollectorNumber returns a single card with the given
 // set code and collector number.
 func (c *Client) GetCardBySetCodeAndCollectorNumber(ctx context.Context, setCode string, collectorNumber string) (Card, error) {
@@ -1152,6 +1698,13 @@ func (c *Client) GetCardByTCGPlayerID(ctx context.Context, tcgPlayerID int) (Car
 	return c.getCard(ctx, cardURL)
 }
 
+// GetCardByCardmarketID returns a single card with the given Cardmarket ID,
+// also known as the idProduct on Cardmarket's API.
+func (c *Client) GetCardByCardmarketID(ctx context.Context, cardmarketID int) (Card, error) {
+	cardURL := fmt.Sprintf("cards/cardmarket/%d", cardmarketID)
+	return c.getCard(ctx, cardURL)
+}
+
 // GetCard returns a single card with the given Scryfall ID.
 func (c *Client) GetCard(ctx context.Context, id string) (Card, error) {
 	cardURL := fmt.Sprintf("cards/%s", id)
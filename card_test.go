@@ -2,6 +2,7 @@ package scryfall
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"reflect"
 	"testing"
@@ -23,6 +24,7 @@ var duskDawn = Card{
 	URI:           "https://api.scryfall.com/cards/937dbc51-b589-4237-9fce-ea5c757f7c48",
 	ScryfallURI:   "https://scryfall.com/card/akh/210/dusk-dawn?utm_source=api",
 	TCGPlayerID:   intPointer(129823),
+	CardMarketID:  intPointer(296759),
 	Layout:        LayoutSplit,
 	HighresImage:  true,
 	ImageURIs: &ImageURIs{
@@ -90,13 +92,14 @@ var duskDawn = Card{
 	PrintsSearchURI: "https://api.scryfall.com/cards/search?order=released&q=oracleid%3A7bc3f92f-68a2-4934-afc4-89f6d0e8cf98&unique=prints",
 	CollectorNumber: "210",
 	Digital:         false,
-	Rarity:          "rare",
+	Rarity:          RarityRare,
 	IllustrationID:  stringPointer("f3d63aed-2784-4ef5-9676-846b1e65e040"),
 	Artist:          stringPointer("Noah Bradley"),
 	Frame:           Frame2015,
 	FrameEffects:    nil,
 	FullArt:         false,
-	BorderColor:     "black",
+	BorderColor:     BorderColorBlack,
+	SecurityStamp:   (*SecurityStamp)(stringPointer(string(SecurityStampOval))),
 	EDHRECRank:      intPointer(830),
 	Prices: Prices{
 		USD:     "0.35",
@@ -249,6 +252,55 @@ func TestAutocompleteCard(t *testing.T) {
 	}
 }
 
+func TestAutocompleteCardWithOptions(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("q") != "thal" || query.Get("include_extras") != "true" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Write([]byte(`{"object": "catalog", "total_items": 20, "data": ["Thallid", "Thorn Thallid", "Thalakos Seer", "Thalakos Scout", "Thalia's Lancers", "Thalakos Sentry", "Thallid Devourer", "Thalakos Deceiver", "Thalakos Drifters", "Thalakos Lowlands", "Thalakos Mistfolk", "Thallid Soothsayer", "Thallid Germinator", "Thalia's Lieutenant", "Thallid Shell-Dweller", "Thalia, Heretic Cathar", "Thalakos Dreamsower", "Thalia, Guardian of Thraben", "Tukatongue Thallid", "Lethal Sting"]}`))
+	})
+	client, ts, err := setupTestServer("/cards/autocomplete", handler)
+	if err != nil {
+		t.Fatalf("Error setting up test server: %v", err)
+	}
+	defer ts.Close()
+
+	ctx := context.Background()
+	autocompletions, err := client.AutocompleteCardWithOptions(ctx, "thal", AutocompleteCardOptions{IncludeExtras: true})
+	if err != nil {
+		t.Fatalf("Error auto completing card: %v", err)
+	}
+
+	want := []string{
+		"Thallid",
+		"Thorn Thallid",
+		"Thalakos Seer",
+		"Thalakos Scout",
+		"Thalia's Lancers",
+		"Thalakos Sentry",
+		"Thallid Devourer",
+		"Thalakos Deceiver",
+		"Thalakos Drifters",
+		"Thalakos Lowlands",
+		"Thalakos Mistfolk",
+		"Thallid Soothsayer",
+		"Thallid Germinator",
+		"Thalia's Lieutenant",
+		"Thallid Shell-Dweller",
+		"Thalia, Heretic Cathar",
+		"Thalakos Dreamsower",
+		"Thalia, Guardian of Thraben",
+		"Tukatongue Thallid",
+		"Lethal Sting",
+	}
+	if !reflect.DeepEqual(autocompletions, want) {
+		t.Errorf("got: %#v want: %#v", autocompletions, want)
+	}
+}
+
 func TestGetRandomCard(t *testing.T) {
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte(duskDawnJSON))
@@ -270,6 +322,31 @@ func TestGetRandomCard(t *testing.T) {
 	}
 }
 
+func TestGetRandomCardWithOptions(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("q") != "t:sorcery" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(duskDawnJSON))
+	})
+	client, ts, err := setupTestServer("/cards/random", handler)
+	if err != nil {
+		t.Fatalf("Error setting up test server: %v", err)
+	}
+	defer ts.Close()
+
+	ctx := context.Background()
+	card, err := client.GetRandomCardWithOptions(ctx, GetRandomCardOptions{Query: "t:sorcery"})
+	if err != nil {
+		t.Fatalf("Error getting card: %v", err)
+	}
+
+	if !reflect.DeepEqual(card, duskDawn) {
+		t.Errorf("got: %#v want: %#v", card, duskDawn)
+	}
+}
+
 func TestGetCardByMultiverseID(t *testing.T) {
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte(duskDawnJSON))
@@ -312,6 +389,27 @@ func TestGetCardByArenaID(t *testing.T) {
 	}
 }
 
+func TestGetCardByCardmarketID(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(duskDawnJSON))
+	})
+	client, ts, err := setupTestServer("/cards/cardmarket/296759", handler)
+	if err != nil {
+		t.Fatalf("Error setting up test server: %v", err)
+	}
+	defer ts.Close()
+
+	ctx := context.Background()
+	card, err := client.GetCardByCardmarketID(ctx, 296759)
+	if err != nil {
+		t.Fatalf("Error getting card: %v", err)
+	}
+
+	if !reflect.DeepEqual(card, duskDawn) {
+		t.Errorf("got: %#v want: %#v", card, duskDawn)
+	}
+}
+
 func TestGetCardByMTGOID(t *testing.T) {
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte(duskDawnJSON))
@@ -374,3 +472,71 @@ func TestGetCard(t *testing.T) {
 		t.Errorf("got: %#v want: %#v", card, duskDawn)
 	}
 }
+
+func TestRarityCompare(t *testing.T) {
+	tests := []struct {
+		r     Rarity
+		other Rarity
+		want  int
+	}{
+		{RarityCommon, RarityUncommon, -1},
+		{RarityMythic, RarityRare, 1},
+		{RaritySpecial, RarityMythic, -1},
+		{RarityBonus, RarityMythic, 1},
+		{RarityRare, RarityRare, 0},
+		{"epic", RarityBonus, 1},
+		{RarityCommon, "epic", -1},
+		{"epic", "legendary", -1},
+		{"epic", "epic", 0},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.r)+" "+string(test.other), func(t *testing.T) {
+			got := test.r.Compare(test.other)
+			if got != test.want {
+				t.Errorf("got: %d want: %d", got, test.want)
+			}
+			if less := test.r.Less(test.other); less != (test.want < 0) {
+				t.Errorf("got less: %t want: %t", less, test.want < 0)
+			}
+		})
+	}
+}
+
+func TestUnknownEnumValues(t *testing.T) {
+	var card Card
+	err := json.Unmarshal([]byte(`{"rarity": "epic", "border_color": "chrome", "security_stamp": "star", "promo_types": ["stamped", "sparkle"]}`), &card)
+	if err != nil {
+		t.Fatalf("Error decoding card: %v", err)
+	}
+
+	if card.Rarity != "epic" || card.Rarity.Known() {
+		t.Errorf("got rarity: %q known: %t", card.Rarity, card.Rarity.Known())
+	}
+	if card.BorderColor != "chrome" || card.BorderColor.Known() {
+		t.Errorf("got border color: %q known: %t", card.BorderColor, card.BorderColor.Known())
+	}
+	if card.SecurityStamp == nil || *card.SecurityStamp != "star" || card.SecurityStamp.Known() {
+		t.Errorf("got security stamp: %v", card.SecurityStamp)
+	}
+	wantPromoTypes := []PromoType{PromoTypeStamped, "sparkle"}
+	if !reflect.DeepEqual(card.PromoTypes, wantPromoTypes) {
+		t.Errorf("got promo types: %#v want: %#v", card.PromoTypes, wantPromoTypes)
+	}
+	if !card.PromoTypes[0].Known() || card.PromoTypes[1].Known() {
+		t.Errorf("got promo types known: %t %t", card.PromoTypes[0].Known(), card.PromoTypes[1].Known())
+	}
+
+	b, err := json.Marshal(card)
+	if err != nil {
+		t.Fatalf("Error encoding card: %v", err)
+	}
+	var got Card
+	err = json.Unmarshal(b, &got)
+	if err != nil {
+		t.Fatalf("Error decoding card: %v", err)
+	}
+	if !reflect.DeepEqual(got, card) {
+		t.Errorf("got: %#v want: %#v", got, card)
+	}
+}
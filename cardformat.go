@@ -0,0 +1,190 @@
+package scryfall
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	qs "github.com/google/go-querystring/query"
+)
+
+const (
+	cardFormatImage = "image"
+	cardFormatText  = "text"
+)
+
+// ImageFace selects which face of a double-sided card the image endpoints
+// return.
+type ImageFace string
+
+const (
+	// ImageFaceFront selects the front face. It is the default.
+	ImageFaceFront ImageFace = ""
+
+	// ImageFaceBack selects the back face of a double-sided card.
+	ImageFaceBack ImageFace = "back"
+)
+
+// CardImageOptions holds the options used to get a card's image from one of
+// the single card endpoints.
+type CardImageOptions struct {
+	// Face is the face of a double-sided card to return. The default face
+	// is ImageFaceFront.
+	Face ImageFace `url:"face,omitempty"`
+
+	// Version is the image version to return. The default version is
+	// ImageVersionLarge.
+	Version ImageVersion `url:"version,omitempty"`
+}
+
+// GetCardAsImage returns the image of the card with the given Scryfall ID. The
+// image endpoints don't report the card's image status, so the Status of the
+// returned image is empty.
+func (c *Client) GetCardAsImage(ctx context.Context, id string, opts CardImageOptions) (CardImage, error) {
+	cardURL := fmt.Sprintf("cards/%s", id)
+	return c.getCardImage(ctx, cardURL, url.Values{}, opts)
+}
+
+// GetCardAsText returns Scryfall's plain text rendering of the card with the
+// given Scryfall ID.
+func (c *Client) GetCardAsText(ctx context.Context, id string) (string, error) {
+	cardURL := fmt.Sprintf("cards/%s", id)
+	return c.getCardText(ctx, cardURL, url.Values{})
+}
+
+// GetCardByNameAsImage returns the image of the card found by a name search
+// string. See GetCardByName for how the name is matched, and GetCardAsImage
+// for the returned image. The image options select the returned image.
+func (c *Client) GetCardByNameAsImage(ctx context.Context, name string, exact bool, opts GetCardByNameOptions, imageOpts CardImageOptions) (CardImage, error) {
+	values, err := cardByNameValues(name, exact, opts)
+	if err != nil {
+		return CardImage{}, err
+	}
+
+	return c.getCardImage(ctx, "cards/named", values, imageOpts)
+}
+
+// GetCardByNameAsText returns Scryfall's plain text rendering of the card found
+// by a name search string. See GetCardByName for how the name is matched.
+func (c *Client) GetCardByNameAsText(ctx context.Context, name string, exact bool, opts GetCardByNameOptions) (string, error) {
+	values, err := cardByNameValues(name, exact, opts)
+	if err != nil {
+		return "", err
+	}
+
+	return c.getCardText(ctx, "cards/named", values)
+}
+
+// GetCardBySetCodeAndCollectorNumberAsImage returns the image of the card with
+// the given set code and collector number. See GetCardAsImage for the returned
+// image.
+func (c *Client) GetCardBySetCodeAndCollectorNumberAsImage(ctx context.Context, setCode string, collectorNumber string, opts CardImageOptions) (CardImage, error) {
+	cardURL := fmt.Sprintf("cards/%s/%s", setCode, url.PathEscape(collectorNumber))
+	return c.getCardImage(ctx, cardURL, url.Values{}, opts)
+}
+
+// GetCardBySetCodeAndCollectorNumberAsText returns Scryfall's plain text
+// rendering of the card with the given set code and collector number.
+func (c *Client) GetCardBySetCodeAndCollectorNumberAsText(ctx context.Context, setCode string, collectorNumber string) (string, error) {
+	cardURL := fmt.Sprintf("cards/%s/%s", setCode, url.PathEscape(collectorNumber))
+	return c.getCardText(ctx, cardURL, url.Values{})
+}
+
+func cardByNameValues(name string, exact bool, opts GetCardByNameOptions) (url.Values, error) {
+	values, err := qs.Values(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if exact {
+		values.Set("exact", name)
+	} else {
+		values.Set("fuzzy", name)
+	}
+
+	return values, nil
+}
+
+func (c *Client) getCardImage(ctx context.Context, cardURL string, values url.Values, opts CardImageOptions) (CardImage, error) {
+	imageValues, err := qs.Values(opts)
+	if err != nil {
+		return CardImage{}, err
+	}
+	for key, value := range imageValues {
+		values[key] = value
+	}
+	values.Set("format", cardFormatImage)
+	data, contentType, imageURI, err := c.getFormatted(ctx, cardURL, values)
+	if err != nil {
+		return CardImage{}, err
+	}
+
+	version := opts.Version
+	if len(version) == 0 {
+		version = ImageVersionLarge
+	}
+	faceIndex := 0
+	if opts.Face == ImageFaceBack {
+		faceIndex = 1
+	}
+
+	cardImage := CardImage{
+		URI:         imageURI,
+		Version:     version,
+		Face:        faceIndex,
+		ContentType: contentType,
+		Data:        data,
+	}
+	return cardImage, nil
+}
+
+func (c *Client) getCardText(ctx context.Context, cardURL string, values url.Values) (string, error) {
+	values.Set("format", cardFormatText)
+	data, _, _, err := c.getFormatted(ctx, cardURL, values)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// getFormatted gets a single card endpoint in a format other than JSON and
+// returns the response body, its MIME type, and the URI it was served from
+// after following redirects. Errors are still reported by Scryfall as JSON.
+func (c *Client) getFormatted(ctx context.Context, relativeURL string, values url.Values) ([]byte, string, string, error) {
+	absoluteURL, err := c.baseURL.Parse(fmt.Sprintf("%s?%s", relativeURL, values.Encode()))
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, absoluteURL.String(), nil)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	resp, err := c.do(ctx, req)
+	if err != nil {
+		return nil, "", "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		scryfallErr := &Error{}
+		err = json.NewDecoder(resp.Body).Decode(scryfallErr)
+		if err != nil {
+			return nil, "", "", fmt.Errorf("getting %s: unexpected status %s", absoluteURL, resp.Status)
+		}
+
+		return nil, "", "", scryfallErr
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	return data, resp.Header.Get("Content-Type"), resp.Request.URL.String(), nil
+}
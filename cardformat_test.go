@@ -0,0 +1,162 @@
+package scryfall
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestGetCardByNameAsImage(t *testing.T) {
+	png := testPNG(t, 2, 2)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cards/named":
+			query := r.URL.Query()
+			if query.Get("format") != "image" || query.Get("exact") != "Delver of Secrets" || query.Get("face") != "back" || query.Get("version") != "png" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			http.Redirect(w, r, "/back/delver.png", http.StatusFound)
+		case "/back/delver.png":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write(png)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	client, ts, err := setupTestServer("/", handler)
+	if err != nil {
+		t.Fatalf("Error setting up test server: %v", err)
+	}
+	defer ts.Close()
+
+	ctx := context.Background()
+	opts := CardImageOptions{
+		Face:    ImageFaceBack,
+		Version: ImageVersionPNG,
+	}
+	cardImage, err := client.GetCardByNameAsImage(ctx, "Delver of Secrets", true, GetCardByNameOptions{}, opts)
+	if err != nil {
+		t.Fatalf("Error getting card image: %v", err)
+	}
+
+	if cardImage.URI != ts.URL+"/back/delver.png" {
+		t.Errorf("got URI %s want %s", cardImage.URI, ts.URL+"/back/delver.png")
+	}
+	if cardImage.Face != 1 || cardImage.Version != ImageVersionPNG || cardImage.ContentType != "image/png" || len(cardImage.Status) != 0 {
+		t.Errorf("got: %#v", cardImage)
+	}
+	if !bytes.Equal(cardImage.Data, png) {
+		t.Errorf("image data doesn't match")
+	}
+}
+
+func TestCardImageOptions(t *testing.T) {
+	png := testPNG(t, 2, 2)
+	var query url.Values
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(png)
+	})
+	client, ts, err := setupTestServer("/", handler)
+	if err != nil {
+		t.Fatalf("Error setting up test server: %v", err)
+	}
+	defer ts.Close()
+
+	ctx := context.Background()
+	getImages := map[string]func(CardImageOptions) (CardImage, error){
+		"id": func(opts CardImageOptions) (CardImage, error) {
+			return client.GetCardAsImage(ctx, "937dbc51-b589-4237-9fce-ea5c757f7c48", opts)
+		},
+		"name": func(opts CardImageOptions) (CardImage, error) {
+			return client.GetCardByNameAsImage(ctx, "Dusk", false, GetCardByNameOptions{}, opts)
+		},
+		"set and number": func(opts CardImageOptions) (CardImage, error) {
+			return client.GetCardBySetCodeAndCollectorNumberAsImage(ctx, "akh", "210", opts)
+		},
+	}
+	tests := []struct {
+		name        string
+		opts        CardImageOptions
+		wantFace    string
+		wantVersion string
+		face        int
+		version     ImageVersion
+	}{
+		{"default", CardImageOptions{}, "", "", 0, ImageVersionLarge},
+		{"front", CardImageOptions{Face: ImageFaceFront, Version: ImageVersionSmall}, "", "small", 0, ImageVersionSmall},
+		{"back", CardImageOptions{Face: ImageFaceBack, Version: ImageVersionArtCrop}, "back", "art_crop", 1, ImageVersionArtCrop},
+	}
+
+	for endpoint, getImage := range getImages {
+		for _, test := range tests {
+			t.Run(endpoint+" "+test.name, func(t *testing.T) {
+				cardImage, err := getImage(test.opts)
+				if err != nil {
+					t.Fatalf("Error getting card image: %v", err)
+				}
+				if query.Get("face") != test.wantFace || query.Get("version") != test.wantVersion || query.Get("format") != "image" {
+					t.Errorf("got query %v want face %q and version %q", query, test.wantFace, test.wantVersion)
+				}
+				if cardImage.Face != test.face || cardImage.Version != test.version {
+					t.Errorf("got face %d version %s want face %d version %s", cardImage.Face, cardImage.Version, test.face, test.version)
+				}
+			})
+		}
+	}
+}
+
+func TestGetCardBySetCodeAndCollectorNumberAsText(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("format") != "text" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		fmt.Fprint(w, "Dusk {2}{W}{W}\nSorcery\nDestroy all creatures with power 3 or greater.")
+	})
+	client, ts, err := setupTestServer("/cards/akh/210", handler)
+	if err != nil {
+		t.Fatalf("Error setting up test server: %v", err)
+	}
+	defer ts.Close()
+
+	ctx := context.Background()
+	text, err := client.GetCardBySetCodeAndCollectorNumberAsText(ctx, "akh", "210")
+	if err != nil {
+		t.Fatalf("Error getting card text: %v", err)
+	}
+
+	want := "Dusk {2}{W}{W}\nSorcery\nDestroy all creatures with power 3 or greater."
+	if text != want {
+		t.Errorf("got: %q want: %q", text, want)
+	}
+}
+
+func TestGetCardAsImageError(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintln(w, `{"object": "error", "code": "not_found", "status": 404, "details": "No card found with the given ID or set code and collector number."}`)
+	})
+	client, ts, err := setupTestServer("/cards/", handler)
+	if err != nil {
+		t.Fatalf("Error setting up test server: %v", err)
+	}
+	defer ts.Close()
+
+	ctx := context.Background()
+	_, err = client.GetCardAsImage(ctx, "00000000-0000-0000-0000-000000000000", CardImageOptions{})
+	scryfallErr := &Error{}
+	if !errors.As(err, &scryfallErr) {
+		t.Fatalf("got error %v, want a Scryfall error", err)
+	}
+	if scryfallErr.Code != "not_found" {
+		t.Errorf("got code %s want not_found", scryfallErr.Code)
+	}
+}
@@ -10,7 +10,7 @@ import (
 // software and understanding possible values for a field on Card objects.
 type Catalog struct {
 	// URI is a link to the current catalog on Scryfall's API.
-	URI string `json:"uri"`
+	URI string `json:"uri,omitempty"`
 
 	// TotalValues is the number of items in the data array.
 	TotalValues int `json:"total_values"`
@@ -19,6 +19,12 @@ type Catalog struct {
 	Data []string `json:"data"`
 }
 
+// MarshalJSON encodes the catalog in Scryfall's format.
+func (c Catalog) MarshalJSON() ([]byte, error) {
+	type catalog Catalog
+	return encodeWithUnknownFields((*catalog)(&c), "catalog", nil)
+}
+
 func (c *Client) getCatalog(ctx context.Context, name string) (Catalog, error) {
 	catalogURL := fmt.Sprintf("catalog/%s", name)
 	catalog := Catalog{}
@@ -0,0 +1,233 @@
+package scryfall
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/BlueMonday/go-scryfall/internal/fsutil"
+)
+
+// CatalogName is the name of one of Scryfall's catalogs.
+type CatalogName string
+
+const (
+	// CatalogCardNames is the catalog of nontoken English card names.
+	CatalogCardNames CatalogName = "card-names"
+
+	// CatalogArtistNames is the catalog of canonical artist names.
+	CatalogArtistNames CatalogName = "artist-names"
+
+	// CatalogWordBank is the catalog of English words that could appear in
+	// a card name.
+	CatalogWordBank CatalogName = "word-bank"
+
+	// CatalogSuperTypes is the catalog of card supertypes.
+	CatalogSuperTypes CatalogName = "supertypes"
+
+	// CatalogCardTypes is the catalog of card types.
+	CatalogCardTypes CatalogName = "card-types"
+
+	// CatalogArtifactTypes is the catalog of artifact types.
+	CatalogArtifactTypes CatalogName = "artifact-types"
+
+	// CatalogBattleTypes is the catalog of battle types.
+	CatalogBattleTypes CatalogName = "battle-types"
+
+	// CatalogCreatureTypes is the catalog of creature types.
+	CatalogCreatureTypes CatalogName = "creature-types"
+
+	// CatalogEnchantmentTypes is the catalog of enchantment types.
+	CatalogEnchantmentTypes CatalogName = "enchantment-types"
+
+	// CatalogLandTypes is the catalog of land types.
+	CatalogLandTypes CatalogName = "land-types"
+
+	// CatalogPlaneswalkerTypes is the catalog of planeswalker types.
+	CatalogPlaneswalkerTypes CatalogName = "planeswalker-types"
+
+	// CatalogSpellTypes is the catalog of instant and sorcery types.
+	CatalogSpellTypes CatalogName = "spell-types"
+
+	// CatalogPowers is the catalog of possible power values.
+	CatalogPowers CatalogName = "powers"
+
+	// CatalogToughnesses is the catalog of possible toughness values.
+	CatalogToughnesses CatalogName = "toughnesses"
+
+	// CatalogLoyalties is the catalog of possible loyalty values.
+	CatalogLoyalties CatalogName = "loyalties"
+
+	// CatalogKeywordAbilities is the catalog of keyword abilities.
+	CatalogKeywordAbilities CatalogName = "keyword-abilities"
+
+	// CatalogKeywordActions is the catalog of keyword actions.
+	CatalogKeywordActions CatalogName = "keyword-actions"
+
+	// CatalogAbilityWords is the catalog of ability words.
+	CatalogAbilityWords CatalogName = "ability-words"
+
+	// CatalogFlavorWords is the catalog of flavor words.
+	CatalogFlavorWords CatalogName = "flavor-words"
+
+	// CatalogWatermarks is the catalog of watermarks.
+	CatalogWatermarks CatalogName = "watermarks"
+)
+
+// CatalogNames is the name of every catalog provided by Scryfall.
+var CatalogNames = []CatalogName{
+	CatalogCardNames,
+	CatalogArtistNames,
+	CatalogWordBank,
+	CatalogSuperTypes,
+	CatalogCardTypes,
+	CatalogArtifactTypes,
+	CatalogBattleTypes,
+	CatalogCreatureTypes,
+	CatalogEnchantmentTypes,
+	CatalogLandTypes,
+	CatalogPlaneswalkerTypes,
+	CatalogSpellTypes,
+	CatalogPowers,
+	CatalogToughnesses,
+	CatalogLoyalties,
+	CatalogKeywordAbilities,
+	CatalogKeywordActions,
+	CatalogAbilityWords,
+	CatalogFlavorWords,
+	CatalogWatermarks,
+}
+
+// GetCatalog returns the catalog with the given name.
+func (c *Client) GetCatalog(ctx context.Context, name CatalogName) (Catalog, error) {
+	return c.getCatalog(ctx, string(name))
+}
+
+// CatalogSnapshot is a set of catalogs fetched together, which can be stored
+// on disk and compared with a later snapshot to find new values.
+type CatalogSnapshot struct {
+	// FetchedAt is the time the catalogs were fetched.
+	FetchedAt time.Time `json:"fetched_at"`
+
+	// Catalogs holds the catalogs in the snapshot keyed by name.
+	Catalogs map[CatalogName]Catalog `json:"catalogs"`
+}
+
+// GetCatalogSnapshot fetches the catalogs with the given names. If no names
+// are given, every catalog in CatalogNames is fetched.
+func (c *Client) GetCatalogSnapshot(ctx context.Context, names ...CatalogName) (CatalogSnapshot, error) {
+	if len(names) == 0 {
+		names = CatalogNames
+	}
+
+	snapshot := CatalogSnapshot{
+		FetchedAt: time.Now().UTC(),
+		Catalogs:  make(map[CatalogName]Catalog, len(names)),
+	}
+	for _, name := range names {
+		catalog, err := c.GetCatalog(ctx, name)
+		if err != nil {
+			return CatalogSnapshot{}, err
+		}
+		snapshot.Catalogs[name] = catalog
+	}
+
+	return snapshot, nil
+}
+
+// Write writes the snapshot as JSON.
+func (s CatalogSnapshot) Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(s)
+}
+
+// WriteFile atomically writes the snapshot as JSON to the named file.
+func (s CatalogSnapshot) WriteFile(path string) error {
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return fsutil.WriteFileAtomic(path, append(b, '\n'), 0o644)
+}
+
+// ReadCatalogSnapshot decodes a snapshot written by CatalogSnapshot.Write.
+func ReadCatalogSnapshot(r io.Reader) (CatalogSnapshot, error) {
+	snapshot := CatalogSnapshot{}
+	err := json.NewDecoder(r).Decode(&snapshot)
+	if err != nil {
+		return CatalogSnapshot{}, err
+	}
+
+	return snapshot, nil
+}
+
+// ReadCatalogSnapshotFile decodes a snapshot written by
+// CatalogSnapshot.WriteFile.
+func ReadCatalogSnapshotFile(path string) (CatalogSnapshot, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return CatalogSnapshot{}, err
+	}
+	defer f.Close()
+
+	return ReadCatalogSnapshot(f)
+}
+
+// CatalogChange is the difference between two versions of a catalog.
+type CatalogChange struct {
+	// Added is the sorted list of values only in the newer catalog.
+	Added []string
+
+	// Removed is the sorted list of values only in the older catalog.
+	Removed []string
+}
+
+// DiffCatalogSnapshots compares an older snapshot with a newer one and returns
+// the changes of every catalog whose values differ, keyed by catalog name. A
+// catalog missing from one of the snapshots is treated as empty.
+func DiffCatalogSnapshots(before, after CatalogSnapshot) map[CatalogName]CatalogChange {
+	names := map[CatalogName]bool{}
+	for name := range before.Catalogs {
+		names[name] = true
+	}
+	for name := range after.Catalogs {
+		names[name] = true
+	}
+
+	diff := map[CatalogName]CatalogChange{}
+	for name := range names {
+		change := CatalogChange{
+			Added:   missingValues(after.Catalogs[name].Data, before.Catalogs[name].Data),
+			Removed: missingValues(before.Catalogs[name].Data, after.Catalogs[name].Data),
+		}
+		if len(change.Added) != 0 || len(change.Removed) != 0 {
+			diff[name] = change
+		}
+	}
+
+	return diff
+}
+
+// missingValues returns the sorted values in values which aren't in other.
+func missingValues(values, other []string) []string {
+	seen := make(map[string]bool, len(other))
+	for _, value := range other {
+		seen[value] = true
+	}
+
+	missing := []string{}
+	for _, value := range values {
+		if !seen[value] {
+			missing = append(missing, value)
+			seen[value] = true
+		}
+	}
+	sort.Strings(missing)
+
+	return missing
+}
@@ -0,0 +1,89 @@
+package scryfall
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGetCatalogSnapshot(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(r.URL.Path, "/catalog/")
+		fmt.Fprintf(w, `{"object": "catalog", "uri": "https://api.scryfall.com/catalog/%s", "total_values": 1, "data": ["%s"]}`, name, name)
+	})
+	client, ts, err := setupTestServer("/catalog/", handler)
+	if err != nil {
+		t.Fatalf("Error setting up test server: %v", err)
+	}
+	defer ts.Close()
+
+	ctx := context.Background()
+	snapshot, err := client.GetCatalogSnapshot(ctx)
+	if err != nil {
+		t.Fatalf("Error getting catalog snapshot: %v", err)
+	}
+	if len(snapshot.Catalogs) != len(CatalogNames) {
+		t.Fatalf("got %d catalogs, want %d", len(snapshot.Catalogs), len(CatalogNames))
+	}
+
+	path := filepath.Join(t.TempDir(), "catalogs.json")
+	err = snapshot.WriteFile(path)
+	if err != nil {
+		t.Fatalf("Error writing catalog snapshot: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Error reading catalog snapshot mode: %v", err)
+	}
+	if mode := info.Mode().Perm(); mode != 0o644 {
+		t.Errorf("got mode %v want %v", mode, os.FileMode(0o644))
+	}
+	got, err := ReadCatalogSnapshotFile(path)
+	if err != nil {
+		t.Fatalf("Error reading catalog snapshot: %v", err)
+	}
+
+	want := Catalog{
+		URI:         "https://api.scryfall.com/catalog/watermarks",
+		TotalValues: 1,
+		Data:        []string{"watermarks"},
+	}
+	if !reflect.DeepEqual(got.Catalogs[CatalogWatermarks], want) {
+		t.Errorf("got: %#v want: %#v", got.Catalogs[CatalogWatermarks], want)
+	}
+	if !got.FetchedAt.Equal(snapshot.FetchedAt) {
+		t.Errorf("got fetched at %v want %v", got.FetchedAt, snapshot.FetchedAt)
+	}
+}
+
+func TestDiffCatalogSnapshots(t *testing.T) {
+	before := CatalogSnapshot{
+		Catalogs: map[CatalogName]Catalog{
+			CatalogCreatureTypes:    {Data: []string{"Elf", "Goblin", "Human"}},
+			CatalogKeywordAbilities: {Data: []string{"Flying", "Trample"}},
+			CatalogWatermarks:       {Data: []string{"abzan"}},
+		},
+	}
+	after := CatalogSnapshot{
+		Catalogs: map[CatalogName]Catalog{
+			CatalogCreatureTypes:    {Data: []string{"Elf", "Human", "Time Lord", "Detective"}},
+			CatalogKeywordAbilities: {Data: []string{"Flying", "Trample"}},
+			CatalogLandTypes:        {Data: []string{"Cave"}},
+		},
+	}
+
+	want := map[CatalogName]CatalogChange{
+		CatalogCreatureTypes: {Added: []string{"Detective", "Time Lord"}, Removed: []string{"Goblin"}},
+		CatalogLandTypes:     {Added: []string{"Cave"}, Removed: []string{}},
+		CatalogWatermarks:    {Added: []string{}, Removed: []string{"abzan"}},
+	}
+	got := DiffCatalogSnapshots(before, after)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got: %#v want: %#v", got, want)
+	}
+}
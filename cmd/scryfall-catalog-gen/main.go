@@ -0,0 +1,157 @@
+// Command scryfall-catalog-gen turns a catalog snapshot into Go constants for
+// keyword abilities, watermarks, card types, and other catalog values.
+//
+// A snapshot can be written with scryfall.CatalogSnapshot.WriteFile. The
+// command is meant to be run with go generate:
+//
+//	//go:generate go run github.com/BlueMonday/go-scryfall/cmd/scryfall-catalog-gen -snapshot catalogs.json -o catalog_values.go
+//
+// The package of the generated file defaults to the package go generate is run
+// in.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+)
+
+// catalogNaming is how the values of a catalog are named in generated code.
+type catalogNaming struct {
+	// prefix is prepended to the identifier of every value.
+	prefix string
+
+	// list is the name of the slice holding every value.
+	list string
+
+	// description describes a single value.
+	description string
+}
+
+var catalogNamings = map[scryfall.CatalogName]catalogNaming{
+	scryfall.CatalogKeywordAbilities:  {"KeywordAbility", "KeywordAbilities", "keyword ability"},
+	scryfall.CatalogKeywordActions:    {"KeywordAction", "KeywordActions", "keyword action"},
+	scryfall.CatalogAbilityWords:      {"AbilityWord", "AbilityWords", "ability word"},
+	scryfall.CatalogFlavorWords:       {"FlavorWord", "FlavorWords", "flavor word"},
+	scryfall.CatalogWatermarks:        {"Watermark", "Watermarks", "watermark"},
+	scryfall.CatalogSuperTypes:        {"SuperType", "SuperTypes", "supertype"},
+	scryfall.CatalogCardTypes:         {"CardType", "CardTypes", "card type"},
+	scryfall.CatalogArtifactTypes:     {"ArtifactType", "ArtifactTypes", "artifact type"},
+	scryfall.CatalogBattleTypes:       {"BattleType", "BattleTypes", "battle type"},
+	scryfall.CatalogCreatureTypes:     {"CreatureType", "CreatureTypes", "creature type"},
+	scryfall.CatalogEnchantmentTypes:  {"EnchantmentType", "EnchantmentTypes", "enchantment type"},
+	scryfall.CatalogLandTypes:         {"LandType", "LandTypes", "land type"},
+	scryfall.CatalogPlaneswalkerTypes: {"PlaneswalkerType", "PlaneswalkerTypes", "planeswalker type"},
+	scryfall.CatalogSpellTypes:        {"SpellType", "SpellTypes", "spell type"},
+}
+
+const defaultCatalogs = "keyword-abilities,keyword-actions,ability-words,watermarks,supertypes,card-types,artifact-types,battle-types,creature-types,enchantment-types,land-types,planeswalker-types,spell-types"
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("scryfall-catalog-gen: ")
+
+	snapshotPath := flag.String("snapshot", "", "catalog snapshot to generate constants from")
+	output := flag.String("o", "", "output file; defaults to standard output")
+	pkg := flag.String("package", os.Getenv("GOPACKAGE"), "package of the generated file")
+	catalogs := flag.String("catalogs", defaultCatalogs, "comma-separated catalogs to generate constants for")
+	flag.Parse()
+
+	if len(*snapshotPath) == 0 {
+		log.Fatal("-snapshot is required")
+	}
+	if len(*pkg) == 0 {
+		*pkg = "scryfall"
+	}
+
+	snapshot, err := scryfall.ReadCatalogSnapshotFile(*snapshotPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	names := []scryfall.CatalogName{}
+	for _, name := range strings.Split(*catalogs, ",") {
+		names = append(names, scryfall.CatalogName(strings.TrimSpace(name)))
+	}
+
+	src, err := generate(snapshot, *pkg, names, filepath.Base(*snapshotPath))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if len(*output) == 0 {
+		_, err = os.Stdout.Write(src)
+	} else {
+		err = os.WriteFile(*output, src, 0o644)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// generate returns the formatted Go source declaring a constant for every
+// value of the named catalogs in the snapshot.
+func generate(snapshot scryfall.CatalogSnapshot, pkg string, names []scryfall.CatalogName, source string) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "// Code generated by scryfall-catalog-gen from %s; DO NOT EDIT.\n\n", source)
+	fmt.Fprintf(buf, "package %s\n", pkg)
+
+	for _, name := range names {
+		naming, ok := catalogNamings[name]
+		if !ok {
+			return nil, fmt.Errorf("no naming for catalog %q", name)
+		}
+		catalog, ok := snapshot.Catalogs[name]
+		if !ok {
+			return nil, fmt.Errorf("catalog %q missing from snapshot", name)
+		}
+
+		idents := map[string]string{}
+		fmt.Fprintf(buf, "\n// Values of the %s catalog.\nconst (\n", name)
+		for _, value := range catalog.Data {
+			ident := naming.prefix + identifier(value)
+			if other, ok := idents[ident]; ok {
+				return nil, fmt.Errorf("catalog %q values %q and %q both map to %s", name, other, value, ident)
+			}
+			idents[ident] = value
+
+			fmt.Fprintf(buf, "\t// %s is the %s %s.\n", ident, strconv.Quote(value), naming.description)
+			fmt.Fprintf(buf, "\t%s = %s\n", ident, strconv.Quote(value))
+		}
+		fmt.Fprintf(buf, ")\n")
+
+		fmt.Fprintf(buf, "\n// %s is every value of the %s catalog.\nvar %s = []string{\n", naming.list, name, naming.list)
+		for _, value := range catalog.Data {
+			fmt.Fprintf(buf, "\t%s%s,\n", naming.prefix, identifier(value))
+		}
+		fmt.Fprintf(buf, "}\n")
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+// identifier converts a catalog value such as "Assembly-Worker" or "Urza's"
+// into the exported identifier AssemblyWorker or Urzas.
+func identifier(value string) string {
+	value = strings.NewReplacer("'", "", "’", "").Replace(value)
+	words := strings.FieldsFunc(value, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var b strings.Builder
+	for _, word := range words {
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+	return b.String()
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+)
+
+func TestIdentifier(t *testing.T) {
+	tests := []struct {
+		in  string
+		out string
+	}{
+		{"Flying", "Flying"},
+		{"abzan", "Abzan"},
+		{"Assembly-Worker", "AssemblyWorker"},
+		{"Urza's", "Urzas"},
+		{"Time Lord", "TimeLord"},
+		{"first strike", "FirstStrike"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.in, func(t *testing.T) {
+			got := identifier(test.in)
+			if got != test.out {
+				t.Errorf("got: %s want: %s", got, test.out)
+			}
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	snapshot := scryfall.CatalogSnapshot{
+		Catalogs: map[scryfall.CatalogName]scryfall.Catalog{
+			scryfall.CatalogKeywordAbilities: {Data: []string{"Flying", "First strike"}},
+			scryfall.CatalogWatermarks:       {Data: []string{"abzan"}},
+		},
+	}
+
+	src, err := generate(snapshot, "cards", []scryfall.CatalogName{scryfall.CatalogKeywordAbilities, scryfall.CatalogWatermarks}, "catalogs.json")
+	if err != nil {
+		t.Fatalf("Error generating constants: %v", err)
+	}
+
+	for _, want := range []string{
+		"// Code generated by scryfall-catalog-gen from catalogs.json; DO NOT EDIT.",
+		"package cards",
+		`KeywordAbilityFirstStrike = "First strike"`,
+		`WatermarkAbzan = "abzan"`,
+		"var Watermarks = []string{\n\tWatermarkAbzan,\n}",
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("generated source missing %q:\n%s", want, src)
+		}
+	}
+
+	snapshot.Catalogs[scryfall.CatalogWatermarks] = scryfall.Catalog{Data: []string{"abzan", "Abzan"}}
+	_, err = generate(snapshot, "cards", []scryfall.CatalogName{scryfall.CatalogWatermarks}, "catalogs.json")
+	if err == nil {
+		t.Errorf("expected an error for colliding identifiers")
+	}
+}
@@ -0,0 +1,93 @@
+// Command scryfall-mirror serves the Scryfall API locally from bulk data
+// files, see package mirror.
+//
+//	scryfall-mirror -addr localhost:8080 -cards default-cards.json -rulings rulings.json -bulk-data bulk-data.json
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+	"github.com/BlueMonday/go-scryfall/mirror"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("scryfall-mirror: ")
+
+	addr := flag.String("addr", "localhost:8080", "address to listen on")
+	cardsPath := flag.String("cards", "", "card bulk data file")
+	rulingsPath := flag.String("rulings", "", "rulings bulk data file")
+	setsPath := flag.String("sets", "", "JSON array of sets")
+	symbolsPath := flag.String("symbols", "", "JSON array of card symbols")
+	catalogsPath := flag.String("catalogs", "", "catalog snapshot")
+	bulkDataPath := flag.String("bulk-data", "", "JSON array of bulk data items")
+	baseURL := flag.String("base-url", "", "external URL of the mirror, used in next page links")
+	flag.Parse()
+
+	config := mirror.Config{BaseURL: *baseURL}
+	var err error
+	if len(*cardsPath) != 0 {
+		f, err := os.Open(*cardsPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		config.Cards, err = scryfall.LoadCardStore(f)
+		f.Close()
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	if len(*rulingsPath) != 0 {
+		f, err := os.Open(*rulingsPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		config.Rulings, err = scryfall.LoadRulingsIndex(f)
+		f.Close()
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	if len(*setsPath) != 0 {
+		err = decodeFile(*setsPath, &config.Sets)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	if len(*symbolsPath) != 0 {
+		err = decodeFile(*symbolsPath, &config.Symbols)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	if len(*catalogsPath) != 0 {
+		config.Catalogs, err = scryfall.ReadCatalogSnapshotFile(*catalogsPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	if len(*bulkDataPath) != 0 {
+		err = decodeFile(*bulkDataPath, &config.BulkData)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	log.Printf("listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, mirror.NewServer(config)))
+}
+
+func decodeFile(path string, v interface{}) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return json.NewDecoder(f).Decode(v)
+}
@@ -0,0 +1,336 @@
+package scryfall
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// SchemaDrift describes the parts of an API response which the client doesn't
+// model, such as fields or enum values added by Scryfall after this version
+// of the package was released.
+type SchemaDrift struct {
+	// URL is the URL of the request which received the response.
+	URL string
+
+	// UnknownFields are the paths of JSON fields in the response without a
+	// matching struct field, such as "data[].security_stamp". Array
+	// elements are written as [] and map values as .*, so each path is
+	// reported once per response.
+	UnknownFields []string
+
+	// UnknownValues are the values of enum fields in the response which
+	// don't match any of the package's constants.
+	UnknownValues []UnknownValue
+}
+
+// UnknownValue is an unexpected enum value in an API response.
+type UnknownValue struct {
+	// Path is the path of the JSON field holding the value, such as
+	// "frame_effects[]".
+	Path string
+
+	// Type is the name of the enum type, such as "FrameEffect".
+	Type string
+
+	// Value is the unexpected value.
+	Value string
+}
+
+// WithStrictDecoding returns an option which enables strict decoding. In
+// strict mode every JSON response is checked against the types it is decoded
+// into, and the handler is called with a report of any unknown fields and
+// unknown Layout, FrameEffect, SetType, Finish, ImageStatus, Rarity,
+// BorderColor, SecurityStamp, or PromoType values. The handler is only called
+// when drift is found, and requests never fail because of it. The object
+// field Scryfall includes in every object is ignored.
+//
+// Strict decoding buffers and walks every response, so it is intended for
+// monitoring and tests rather than hot paths.
+func WithStrictDecoding(handler func(SchemaDrift)) ClientOption {
+	return func(o *clientOptions) {
+		o.onSchemaDrift = handler
+	}
+}
+
+// knownEnumValues holds the values of the enum types checked by strict
+// decoding.
+var knownEnumValues = map[reflect.Type]map[string]bool{
+	reflect.TypeOf(Layout("")): enumValues(
+		LayoutNormal, LayoutSplit, LayoutFlip, LayoutTransform, LayoutModalDFC,
+		LayoutMeld, LayoutLeveler, LayoutClass, LayoutCase, LayoutSaga,
+		LayoutAdventure, LayoutMutate, LayoutPrototype, LayoutBattle,
+		LayoutPlanar, LayoutScheme, LayoutVanguard, LayoutToken,
+		LayoutDoubleFacedToken, LayoutEmblem, LayoutAugment, LayoutHost,
+		LayoutArtSeries, LayoutReversible,
+	),
+	reflect.TypeOf(FrameEffect("")): enumValues(
+		FrameEffectLegendary, FrameEffectMiracle, FrameEffectNyxTouched,
+		FrameEffectDraft, FrameEffectDevoid, FrameEffectTombstone,
+		FrameEffectColorShifted, FrameEffectInverted, FrameEffectSunMoonDFC,
+		FrameEffectCompassLandDFC, FrameEffectOriginPWDFC,
+		FrameEffectMoonEldraziDFC, FrameEffectMoonReverseMoonDFC,
+		FrameEffectShowcase, FrameEffectExtendedArt, FrameEffectCompanion,
+		FrameEffectEtched, FrameEffectSnow, FrameEffectLesson,
+		FrameEffectShatteredGlass, FrameEffectConvertDFC, FrameEffectFanDFC,
+		FrameEffectUpsideDownDFC, FrameEffectSpree,
+	),
+	reflect.TypeOf(SetType("")): enumValues(
+		SetTypeCore, SetTypeExpansion, SetTypeMasters, SetTypeEternal,
+		SetTypeAlchemy, SetTypeMasterpiece, SetTypeArsenal,
+		SetTypeFromTheVault, SetTypeSpellbook, SetTypePremiumDeck,
+		SetTypeDuelDeck, SetTypeDraftInnovation, SetTypeTreasureChest,
+		SetTypeCommander, SetTypePlanechase, SetTypeArchenemy,
+		SetTypeVanguard, SetTypeFunny, SetTypeStarter, SetTypeBox,
+		SetTypePromo, SetTypeToken, SetTypeMemorabilia, SetTypeMinigame,
+	),
+	reflect.TypeOf(Finish("")): enumValues(
+		FinishFoil, FinishNonFoil, FinishEtched, FinishGlossy,
+	),
+	reflect.TypeOf(ImageStatus("")): enumValues(
+		ImageStatusMissing, ImageStatusPlaceholer, ImageStatusLowres,
+		ImageStatusHighres,
+	),
+	reflect.TypeOf(Rarity("")):        knownRarities,
+	reflect.TypeOf(BorderColor("")):   knownBorderColors,
+	reflect.TypeOf(SecurityStamp("")): knownSecurityStamps,
+	reflect.TypeOf(PromoType("")):     knownPromoTypes,
+}
+
+var (
+	knownRarities = enumValues(
+		RarityCommon, RarityUncommon, RarityRare, RaritySpecial, RarityMythic,
+		RarityBonus,
+	)
+	knownBorderColors = enumValues(
+		BorderColorBlack, BorderColorWhite, BorderColorBorderless,
+		BorderColorSilver, BorderColorGold, BorderColorYellow,
+	)
+	knownSecurityStamps = enumValues(
+		SecurityStampOval, SecurityStampTriangle, SecurityStampAcorn,
+		SecurityStampCircle, SecurityStampArena, SecurityStampHeart,
+	)
+	knownPromoTypes = enumValues(
+		PromoTypeAlchemy, PromoTypeArenaLeague, PromoTypeBoosterFun,
+		PromoTypeBoxTopper, PromoTypeBrawlDeck, PromoTypeBundle,
+		PromoTypeBuyABox, PromoTypeConcept, PromoTypeConfettiFoil,
+		PromoTypeConvention, PromoTypeDatestamped, PromoTypeDoubleRainbow,
+		PromoTypeDraftWeekend, PromoTypeDuels, PromoTypeEmbossed,
+		PromoTypeEvent, PromoTypeFNM, PromoTypeGalaxyFoil, PromoTypeGameDay,
+		PromoTypeGateway, PromoTypeGiftBox, PromoTypeGilded,
+		PromoTypeHaloFoil, PromoTypeInStore, PromoTypeIntroPack,
+		PromoTypeJudgeGift, PromoTypeLeague, PromoTypeNeonInk,
+		PromoTypeOilSlick, PromoTypeOpenHouse, PromoTypePlaneswalkerDeck,
+		PromoTypePlayerRewards, PromoTypePlayPromo, PromoTypePremiereShop,
+		PromoTypePrerelease, PromoTypePromoPack, PromoTypeRainbowFoil,
+		PromoTypeRaisedFoil, PromoTypeRebalanced, PromoTypeRelease,
+		PromoTypeRippleFoil, PromoTypeSerialized, PromoTypeSetPromo,
+		PromoTypeStamped, PromoTypeStarterDeck, PromoTypeStepAndCompleat,
+		PromoTypeSurgeFoil, PromoTypeTextured, PromoTypeThemePack,
+		PromoTypeThick, PromoTypeTourney, PromoTypeWizardsPlayNetwork,
+	)
+)
+
+// enumValues returns the set of the string values of the constants.
+func enumValues(constants ...interface{}) map[string]bool {
+	values := map[string]bool{}
+	for _, constant := range constants {
+		values[reflect.ValueOf(constant).String()] = true
+	}
+	return values
+}
+
+var (
+	rawMessageType  = reflect.TypeOf(json.RawMessage{})
+	unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// driftDetector accumulates the schema drift found in a response.
+type driftDetector struct {
+	unknownFields map[string]bool
+	unknownValues map[UnknownValue]bool
+}
+
+func newDriftDetector() *driftDetector {
+	return &driftDetector{
+		unknownFields: map[string]bool{},
+		unknownValues: map[UnknownValue]bool{},
+	}
+}
+
+// check walks the JSON value b, found at path, against the type t it was
+// decoded into.
+func (d *driftDetector) check(path string, b []byte, t reflect.Type) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if known, ok := knownEnumValues[t]; ok {
+		var value string
+		if json.Unmarshal(b, &value) == nil && !known[value] {
+			d.unknownValues[UnknownValue{Path: path, Type: t.Name(), Value: value}] = true
+		}
+		return
+	}
+	if isOpaque(t) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		var object map[string]json.RawMessage
+		if json.Unmarshal(b, &object) != nil {
+			return
+		}
+		fields := jsonFields(t)
+		for key, value := range object {
+			if key == "object" {
+				continue
+			}
+			field, ok := fields[key]
+			if !ok {
+				field, ok = fields[strings.ToLower(key)]
+			}
+			if !ok {
+				d.unknownFields[joinPath(path, key)] = true
+				continue
+			}
+			d.check(joinPath(path, key), value, field.Type)
+		}
+	case reflect.Slice, reflect.Array:
+		var elems []json.RawMessage
+		if json.Unmarshal(b, &elems) != nil {
+			return
+		}
+		for _, elem := range elems {
+			d.check(path+"[]", elem, t.Elem())
+		}
+	case reflect.Map:
+		var object map[string]json.RawMessage
+		if json.Unmarshal(b, &object) != nil {
+			return
+		}
+		for _, value := range object {
+			d.check(joinPath(path, "*"), value, t.Elem())
+		}
+	}
+}
+
+// isOpaque reports whether values of type t are decoded by their own
+// UnmarshalJSON method.
+func isOpaque(t reflect.Type) bool {
+	return t == rawMessageType || reflect.PtrTo(t).Implements(unmarshalerType)
+}
+
+// report returns the drift found, or false if there wasn't any.
+func (d *driftDetector) report(url string) (SchemaDrift, bool) {
+	if len(d.unknownFields) == 0 && len(d.unknownValues) == 0 {
+		return SchemaDrift{}, false
+	}
+
+	drift := SchemaDrift{URL: url}
+	for field := range d.unknownFields {
+		drift.UnknownFields = append(drift.UnknownFields, field)
+	}
+	sort.Strings(drift.UnknownFields)
+	for value := range d.unknownValues {
+		drift.UnknownValues = append(drift.UnknownValues, value)
+	}
+	sort.Slice(drift.UnknownValues, func(i, j int) bool {
+		if drift.UnknownValues[i].Path != drift.UnknownValues[j].Path {
+			return drift.UnknownValues[i].Path < drift.UnknownValues[j].Path
+		}
+		return drift.UnknownValues[i].Value < drift.UnknownValues[j].Value
+	})
+
+	return drift, true
+}
+
+// jsonFieldsCache holds the result of jsonFields for each struct type, since
+// it is needed for every object decoded.
+var jsonFieldsCache sync.Map
+
+// jsonFields returns the exported fields of the struct type keyed by their
+// JSON names, and by their lower case JSON names to match keys case
+// insensitively like encoding/json. The returned map must not be modified.
+func jsonFields(t reflect.Type) map[string]reflect.StructField {
+	if fields, ok := jsonFieldsCache.Load(t); ok {
+		return fields.(map[string]reflect.StructField)
+	}
+
+	fields := buildJSONFields(t)
+	jsonFieldsCache.Store(t, fields)
+	return fields
+}
+
+func buildJSONFields(t reflect.Type) map[string]reflect.StructField {
+	fields := map[string]reflect.StructField{}
+	lower := map[string]reflect.StructField{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if len(field.PkgPath) != 0 && !field.Anonymous {
+			continue
+		}
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if field.Anonymous && len(name) == 0 && field.Type.Kind() == reflect.Struct {
+			for embeddedName, embeddedField := range jsonFields(field.Type) {
+				// Index the promoted field from t, like FieldByIndex expects.
+				embeddedField.Index = append([]int{i}, embeddedField.Index...)
+				if _, ok := fields[embeddedName]; !ok {
+					fields[embeddedName] = embeddedField
+				}
+			}
+			continue
+		}
+		if len(field.PkgPath) != 0 {
+			continue
+		}
+		if len(name) == 0 {
+			name = field.Name
+		}
+		fields[name] = field
+		lower[strings.ToLower(name)] = field
+	}
+	for name, field := range lower {
+		if _, ok := fields[name]; !ok {
+			fields[name] = field
+		}
+	}
+
+	return fields
+}
+
+func joinPath(path, key string) string {
+	if len(path) == 0 {
+		return key
+	}
+	return path + "." + key
+}
+
+// reportSchemaDrift checks the response b, decoded into v, for schema drift
+// and reports it to the strict decoding handler.
+func (c *Client) reportSchemaDrift(url string, b []byte, v interface{}) {
+	d := newDriftDetector()
+	d.check("", b, reflect.TypeOf(v))
+	if drift, ok := d.report(url); ok {
+		c.onSchemaDrift(drift)
+	}
+}
+
+// reportListSchemaDrift checks the list response, with its data decoded into
+// v, for schema drift and reports it to the strict decoding handler.
+func (c *Client) reportListSchemaDrift(response *listResponse, v interface{}) {
+	d := newDriftDetector()
+	d.check("", response.raw, reflect.TypeOf(response))
+	d.check("data", response.Data, reflect.TypeOf(v))
+	if drift, ok := d.report(response.url); ok {
+		c.onSchemaDrift(drift)
+	}
+}
@@ -0,0 +1,92 @@
+package scryfall
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestStrictDecoding(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		body    string
+		call    func(ctx context.Context, client *Client) error
+		want    []SchemaDrift
+	}{
+		{
+			"card",
+			"/cards/abc",
+			`{"object": "card", "id": "abc", "name": "Lightning Bolt", "layout": "normal", "rarity": "epic", "border_color": "black", "security_stamp": "oval", "promo_types": ["boosterfun", "sparkle"], "frame_effects": ["legendary", "wanted", "wanted"], "finishes": ["nonfoil", "rainbow"], "image_status": "highres_scan", "card_faces": [{"object": "card_face", "name": "Lightning Bolt", "artist_id": "x"}], "legalities": {"modern": "legal"}}`,
+			func(ctx context.Context, client *Client) error {
+				_, err := client.GetCard(ctx, "abc")
+				return err
+			},
+			[]SchemaDrift{
+				{
+					UnknownFields: []string{"card_faces[].artist_id"},
+					UnknownValues: []UnknownValue{
+						{Path: "finishes[]", Type: "Finish", Value: "rainbow"},
+						{Path: "frame_effects[]", Type: "FrameEffect", Value: "wanted"},
+						{Path: "promo_types[]", Type: "PromoType", Value: "sparkle"},
+						{Path: "rarity", Type: "Rarity", Value: "epic"},
+					},
+				},
+			},
+		},
+		{
+			"list",
+			"/sets",
+			`{"object": "list", "has_more": false, "not_found": [], "data": [{"object": "set", "code": "dom", "set_type": "core"}, {"object": "set", "code": "ydmu", "set_type": "alchemy"}, {"object": "set", "code": "zzz", "set_type": "spinoff", "printed_size": 3}]}`,
+			func(ctx context.Context, client *Client) error {
+				_, err := client.ListSets(ctx)
+				return err
+			},
+			[]SchemaDrift{
+				{
+					UnknownFields: []string{"data[].printed_size", "not_found"},
+					UnknownValues: []UnknownValue{{Path: "data[].set_type", Type: "SetType", Value: "spinoff"}},
+				},
+			},
+		},
+		{
+			"no drift",
+			"/cards/abc",
+			`{"object": "card", "id": "abc", "name": "Lightning Bolt", "layout": "normal", "released_at": "2020-01-01", "prices": {"usd": "1.00"}}`,
+			func(ctx context.Context, client *Client) error {
+				_, err := client.GetCard(ctx, "abc")
+				return err
+			},
+			nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintln(w, test.body)
+			})
+			var got []SchemaDrift
+			client, ts, err := setupTestServer(test.pattern, handler, WithStrictDecoding(func(drift SchemaDrift) {
+				got = append(got, drift)
+			}))
+			if err != nil {
+				t.Fatalf("Error setting up test server: %v", err)
+			}
+			defer ts.Close()
+
+			err = test.call(context.Background(), client)
+			if err != nil {
+				t.Fatalf("Error calling API: %v", err)
+			}
+			for i := range test.want {
+				test.want[i].URL = ts.URL + test.pattern
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got: %#v want: %#v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+package scryfall
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Face is a uniform view of one face of a card. Multifaced cards store most of
+// their gameplay data in CardFaces while single-faced cards store it on the Card
+// itself, a Face hides that difference by falling back to the card's top-level
+// fields when a face doesn't provide them.
+type Face struct {
+	// Name is the name of this face.
+	Name string
+
+	// PrintedName is the printed name of this face, if the card is not in
+	// English.
+	PrintedName *string
+
+	// ManaCost is the mana cost of this face. This value will be any empty
+	// string "" if the cost is absent.
+	ManaCost string
+
+	// ManaValue is the mana value of this face. Faces without a mana cost
+	// on the back of a transforming card share the mana value of the front
+	// face, as they do in the game rules.
+	ManaValue float64
+
+	// TypeLine is the type line of this face.
+	TypeLine string
+
+	// PrintedTypeLine is the printed type line of this face, if the card is
+	// not in English.
+	PrintedTypeLine *string
+
+	// OracleText is the Oracle text for this face, if any.
+	OracleText string
+
+	// PrintedText is the printed text for this face, if the card is not in
+	// English.
+	PrintedText *string
+
+	// Colors is this face's colors.
+	Colors []Color
+
+	// ColorIndicator is the colors in this face's color indicator, if any.
+	ColorIndicator []Color
+
+	// Power is this face's power, if any.
+	Power *string
+
+	// Toughness is this face's toughness, if any.
+	Toughness *string
+
+	// Loyalty is this face's loyalty, if any.
+	Loyalty *string
+
+	// Defense is this face's defense, if any.
+	Defense *string
+
+	// FlavorText is the flavor text printed on this face, if any.
+	FlavorText *string
+
+	// IllustrationID is the unique identifier for this face's artwork, if
+	// any.
+	IllustrationID *string
+
+	// ImageURIs contains links to the imagery of this face. Cards which
+	// print every face on the same side share a single image. ImageURIs is
+	// nil if no image is available.
+	ImageURIs *ImageURIs
+}
+
+// transformingLayouts are the layouts whose back face has no mana cost of its
+// own and uses the mana value of the front face.
+var transformingLayouts = map[Layout]bool{
+	LayoutTransform: true,
+	LayoutFlip:      true,
+	LayoutBattle:    true,
+}
+
+// Faces returns a uniform view of every face of the card. Single-faced cards
+// have exactly one face.
+func (c Card) Faces() []Face {
+	if len(c.CardFaces) == 0 {
+		return []Face{c.topLevelFace()}
+	}
+
+	faces := make([]Face, 0, len(c.CardFaces))
+	for i, cardFace := range c.CardFaces {
+		face := Face{
+			Name:            cardFace.Name,
+			PrintedName:     cardFace.PrintedName,
+			ManaCost:        cardFace.ManaCost,
+			ManaValue:       ManaValue(cardFace.ManaCost),
+			TypeLine:        cardFace.TypeLine,
+			PrintedTypeLine: cardFace.PrintedTypeLine,
+			PrintedText:     cardFace.PrintedText,
+			Colors:          cardFace.Colors,
+			ColorIndicator:  cardFace.ColorIndicator,
+			Power:           cardFace.Power,
+			Toughness:       cardFace.Toughness,
+			Loyalty:         cardFace.Loyalty,
+			Defense:         cardFace.Defense,
+			FlavorText:      cardFace.FlavorText,
+			IllustrationID:  cardFace.IllustrationID,
+			ImageURIs:       c.ImageURIs,
+		}
+		if cardFace.OracleText != nil {
+			face.OracleText = *cardFace.OracleText
+		}
+		if face.Colors == nil {
+			face.Colors = c.Colors
+		}
+		if face.IllustrationID == nil && i == 0 {
+			face.IllustrationID = c.IllustrationID
+		}
+		if cardFace.ImageURIs != (ImageURIs{}) {
+			imageURIs := cardFace.ImageURIs
+			face.ImageURIs = &imageURIs
+		}
+		if i > 0 && len(face.ManaCost) == 0 && transformingLayouts[c.Layout] {
+			face.ManaValue = faces[0].ManaValue
+		}
+		faces = append(faces, face)
+	}
+
+	return faces
+}
+
+func (c Card) topLevelFace() Face {
+	return Face{
+		Name:            c.Name,
+		PrintedName:     c.PrintedName,
+		ManaCost:        c.ManaCost,
+		ManaValue:       c.CMC,
+		TypeLine:        c.TypeLine,
+		PrintedTypeLine: c.PrintedTypeLine,
+		OracleText:      c.OracleText,
+		PrintedText:     c.PrintedText,
+		Colors:          c.Colors,
+		ColorIndicator:  c.ColorIndicator,
+		Power:           c.Power,
+		Toughness:       c.Toughness,
+		Loyalty:         c.Loyalty,
+		Defense:         c.Defense,
+		FlavorText:      c.FlavorText,
+		IllustrationID:  c.IllustrationID,
+		ImageURIs:       c.ImageURIs,
+	}
+}
+
+// Front returns the front face of the card. For single-faced cards, this is
+// the card itself.
+func (c Card) Front() Face {
+	return c.Faces()[0]
+}
+
+// Back returns the second face of the card, if it has one.
+func (c Card) Back() (Face, bool) {
+	faces := c.Faces()
+	if len(faces) < 2 {
+		return Face{}, false
+	}
+
+	return faces[1], true
+}
+
+// FaceImageURIs returns the image URIs for the face at index i. Cards which
+// print every face on the same side, such as split and adventure cards, return
+// the card's image for every face.
+func (c Card) FaceImageURIs(i int) (ImageURIs, bool) {
+	faces := c.Faces()
+	if i < 0 || i >= len(faces) || faces[i].ImageURIs == nil {
+		return ImageURIs{}, false
+	}
+
+	return *faces[i].ImageURIs, true
+}
+
+// CombinedOracleText returns the Oracle text of every face of the card joined
+// by a line containing only "//", regardless of the card's layout. Faces
+// without Oracle text are skipped.
+func (c Card) CombinedOracleText() string {
+	texts := []string{}
+	for _, face := range c.Faces() {
+		if len(face.OracleText) != 0 {
+			texts = append(texts, face.OracleText)
+		}
+	}
+
+	return strings.Join(texts, "\n//\n")
+}
+
+// ManaValue returns the mana value of a mana cost such as "{2}{W}{W}". Generic
+// and hybrid symbols count their highest value, X, Y and Z count as zero, and
+// half mana symbols from funny sets count as a half.
+func ManaValue(manaCost string) float64 {
+	total := 0.0
+	for len(manaCost) != 0 {
+		start := strings.IndexByte(manaCost, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(manaCost[start:], '}')
+		if end < 0 {
+			break
+		}
+		total += symbolManaValue(manaCost[start+1 : start+end])
+		manaCost = manaCost[start+end+1:]
+	}
+
+	return total
+}
+
+func symbolManaValue(symbol string) float64 {
+	switch symbol {
+	case "X", "Y", "Z":
+		return 0
+	case "½":
+		return 0.5
+	}
+	if strings.HasPrefix(symbol, "H") {
+		return 0.5
+	}
+
+	value := 0.0
+	for _, part := range strings.Split(symbol, "/") {
+		partValue := 1.0
+		if n, err := strconv.ParseFloat(part, 64); err == nil {
+			partValue = n
+		} else if part == "P" {
+			continue
+		}
+		if partValue > value {
+			value = partValue
+		}
+	}
+
+	return value
+}
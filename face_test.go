@@ -0,0 +1,138 @@
+package scryfall
+
+import (
+	"reflect"
+	"testing"
+)
+
+var delverOfSecrets = Card{
+	Name:     "Delver of Secrets // Insectile Aberration",
+	Layout:   LayoutTransform,
+	CMC:      1,
+	ManaCost: "",
+	CardFaces: []CardFace{
+		{
+			Name:       "Delver of Secrets",
+			ManaCost:   "{U}",
+			TypeLine:   "Creature — Human Wizard",
+			OracleText: stringPointer("At the beginning of your upkeep, look at the top card of your library. You may reveal that card. If an instant or sorcery card is revealed this way, transform Delver of Secrets."),
+			Colors:     []Color{ColorBlue},
+			Power:      stringPointer("1"),
+			Toughness:  stringPointer("1"),
+			ImageURIs:  ImageURIs{Normal: "https://cards.scryfall.io/normal/front/delver.jpg"},
+		},
+		{
+			Name:           "Insectile Aberration",
+			TypeLine:       "Creature — Human Insect",
+			OracleText:     stringPointer("Flying"),
+			Colors:         []Color{ColorBlue},
+			ColorIndicator: []Color{ColorBlue},
+			Power:          stringPointer("3"),
+			Toughness:      stringPointer("2"),
+			ImageURIs:      ImageURIs{Normal: "https://cards.scryfall.io/normal/back/delver.jpg"},
+		},
+	},
+}
+
+func TestCardFacesSingleFaced(t *testing.T) {
+	card := Card{
+		Name:       "Lightning Bolt",
+		ManaCost:   "{R}",
+		CMC:        1,
+		TypeLine:   "Instant",
+		OracleText: "Lightning Bolt deals 3 damage to any target.",
+		Colors:     []Color{ColorRed},
+		ImageURIs:  &ImageURIs{Normal: "https://cards.scryfall.io/normal/front/bolt.jpg"},
+	}
+
+	faces := card.Faces()
+	want := []Face{
+		{
+			Name:       "Lightning Bolt",
+			ManaCost:   "{R}",
+			ManaValue:  1,
+			TypeLine:   "Instant",
+			OracleText: "Lightning Bolt deals 3 damage to any target.",
+			Colors:     []Color{ColorRed},
+			ImageURIs:  card.ImageURIs,
+		},
+	}
+	if !reflect.DeepEqual(faces, want) {
+		t.Errorf("got: %#v want: %#v", faces, want)
+	}
+
+	if _, ok := card.Back(); ok {
+		t.Errorf("single-faced card should not have a back face")
+	}
+}
+
+func TestCardFacesSplit(t *testing.T) {
+	front := duskDawn.Front()
+	back, ok := duskDawn.Back()
+	if !ok {
+		t.Fatalf("split card should have a back face")
+	}
+
+	if front.Name != "Dusk" || front.ManaValue != 4 || !reflect.DeepEqual(front.Colors, []Color{ColorWhite}) {
+		t.Errorf("unexpected front face: %#v", front)
+	}
+	if back.Name != "Dawn" || back.ManaValue != 5 {
+		t.Errorf("unexpected back face: %#v", back)
+	}
+
+	imageURIs, ok := duskDawn.FaceImageURIs(1)
+	if !ok || imageURIs != *duskDawn.ImageURIs {
+		t.Errorf("got: %#v want: %#v", imageURIs, *duskDawn.ImageURIs)
+	}
+}
+
+func TestCardFacesTransform(t *testing.T) {
+	back, ok := delverOfSecrets.Back()
+	if !ok {
+		t.Fatalf("transform card should have a back face")
+	}
+
+	if back.ManaValue != 1 {
+		t.Errorf("got back face mana value: %v want: 1", back.ManaValue)
+	}
+
+	imageURIs, ok := delverOfSecrets.FaceImageURIs(1)
+	if !ok || imageURIs.Normal != "https://cards.scryfall.io/normal/back/delver.jpg" {
+		t.Errorf("unexpected back face image: %#v", imageURIs)
+	}
+
+	if _, ok := delverOfSecrets.FaceImageURIs(2); ok {
+		t.Errorf("unexpected image for missing face")
+	}
+}
+
+func TestCardCombinedOracleText(t *testing.T) {
+	want := "Destroy all creatures with power 3 or greater.\n//\nAftermath (Cast this spell only from your graveyard. Then exile it.)\nReturn all creature cards with power 2 or less from your graveyard to your hand."
+	if got := duskDawn.CombinedOracleText(); got != want {
+		t.Errorf("got: %q want: %q", got, want)
+	}
+}
+
+func TestManaValue(t *testing.T) {
+	tests := []struct {
+		in  string
+		out float64
+	}{
+		{"", 0},
+		{"{0}", 0},
+		{"{2}{W}{W}", 4},
+		{"{X}{R}{R}", 2},
+		{"{2/W}{2/W}", 4},
+		{"{W/P}{G/U}", 2},
+		{"{10}{C}", 11},
+		{"{HW}", 0.5},
+	}
+
+	for _, test := range tests {
+		t.Run(test.in, func(t *testing.T) {
+			if got := ManaValue(test.in); got != test.out {
+				t.Errorf("got: %v want: %v", got, test.out)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package scryfall
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// ErrForeignURI is returned when following a URI embedded in a Scryfall object
+// which doesn't belong to the client's configured base URL.
+var ErrForeignURI = errors.New("URI does not belong to the configured base URL")
+
+// apiURI validates that the URI embedded in a Scryfall object belongs to the
+// client's configured base URL, so following it can't leak the client's
+// credentials to another host.
+func (c *Client) apiURI(uri string) (string, error) {
+	if len(uri) == 0 {
+		return "", ErrForeignURI
+	}
+
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme != c.baseURL.Scheme || u.Host != c.baseURL.Host {
+		return "", ErrForeignURI
+	}
+
+	basePath := strings.TrimSuffix(c.baseURL.Path, "/") + "/"
+	if !strings.HasPrefix(u.Path, basePath) {
+		return "", ErrForeignURI
+	}
+
+	return u.String(), nil
+}
+
+// RefreshCard returns the latest version of the card by following its URI.
+func (c *Client) RefreshCard(ctx context.Context, card Card) (Card, error) {
+	cardURL, err := c.apiURI(card.URI)
+	if err != nil {
+		return Card{}, err
+	}
+
+	return c.getCard(ctx, cardURL)
+}
+
+// GetPrintings returns every re/print of the card by following its
+// PrintsSearchURI through every page of the results.
+func (c *Client) GetPrintings(ctx context.Context, card Card) ([]Card, error) {
+	printsURL, err := c.apiURI(card.PrintsSearchURI)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.listAllCards(ctx, printsURL)
+}
+
+// GetRulingsFor returns the rulings for the card by following its RulingsURI.
+func (c *Client) GetRulingsFor(ctx context.Context, card Card) ([]Ruling, error) {
+	rulingsURL, err := c.apiURI(card.RulingsURI)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.getRulings(ctx, rulingsURL)
+}
+
+// GetSetFor returns the set the card belongs to by following its SetURI.
+func (c *Client) GetSetFor(ctx context.Context, card Card) (Set, error) {
+	setURL, err := c.apiURI(card.SetURI)
+	if err != nil {
+		return Set{}, err
+	}
+
+	set := Set{}
+	err = c.get(ctx, setURL, &set)
+	if err != nil {
+		return Set{}, err
+	}
+
+	return set, nil
+}
+
+// ListSetCardsFor returns every card in the set the card belongs to by
+// following its SetSearchURI through every page of the results.
+func (c *Client) ListSetCardsFor(ctx context.Context, card Card) ([]Card, error) {
+	setSearchURL, err := c.apiURI(card.SetSearchURI)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.listAllCards(ctx, setSearchURL)
+}
+
+// ListSetCards returns every card in the set by following its SearchURI
+// through every page of the results.
+func (c *Client) ListSetCards(ctx context.Context, set Set) ([]Card, error) {
+	searchURL, err := c.apiURI(set.SearchURI)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.listAllCards(ctx, searchURL)
+}
+
+// GetRelatedCard returns the full card object for a related card by following
+// its URI.
+func (c *Client) GetRelatedCard(ctx context.Context, relatedCard RelatedCard) (Card, error) {
+	cardURL, err := c.apiURI(relatedCard.URI)
+	if err != nil {
+		return Card{}, err
+	}
+
+	return c.getCard(ctx, cardURL)
+}
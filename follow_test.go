@@ -0,0 +1,176 @@
+package scryfall
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestGetPrintings(t *testing.T) {
+	var serverURL string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprintln(w, `{"object": "list", "has_more": false, "data": [`+duskDawnJSON+`]}`)
+			return
+		}
+		fmt.Fprintf(w, `{"object": "list", "has_more": true, "next_page": "%s/cards/search?q=dusk&page=2", "data": [%s]}`, serverURL, duskDawnJSON)
+	})
+	client, ts, err := setupTestServer("/cards/search", handler)
+	if err != nil {
+		t.Fatalf("Error setting up test server: %v", err)
+	}
+	defer ts.Close()
+	serverURL = ts.URL
+
+	card := Card{PrintsSearchURI: ts.URL + "/cards/search?q=dusk"}
+	ctx := context.Background()
+	printings, err := client.GetPrintings(ctx, card)
+	if err != nil {
+		t.Fatalf("Error getting printings: %v", err)
+	}
+
+	want := []Card{duskDawn, duskDawn}
+	if !reflect.DeepEqual(printings, want) {
+		t.Errorf("got: %#v want: %#v", printings, want)
+	}
+}
+
+func TestGetPrintingsForeignNextPage(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"object": "list", "has_more": true, "next_page": "https://example.com/cards/search?q=dusk&page=2", "data": [%s]}`, duskDawnJSON)
+	})
+	client, ts, err := setupTestServer("/cards/search", handler)
+	if err != nil {
+		t.Fatalf("Error setting up test server: %v", err)
+	}
+	defer ts.Close()
+
+	card := Card{PrintsSearchURI: ts.URL + "/cards/search?q=dusk"}
+	_, err = client.GetPrintings(context.Background(), card)
+	if err != ErrForeignURI {
+		t.Errorf("got: %v want: %v", err, ErrForeignURI)
+	}
+}
+
+func TestRefreshCard(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, duskDawnJSON)
+	})
+	client, ts, err := setupTestServer("/cards/937dbc51-b589-4237-9fce-ea5c757f7c48", handler)
+	if err != nil {
+		t.Fatalf("Error setting up test server: %v", err)
+	}
+	defer ts.Close()
+
+	card := Card{URI: ts.URL + "/cards/937dbc51-b589-4237-9fce-ea5c757f7c48"}
+	got, err := client.RefreshCard(context.Background(), card)
+	if err != nil {
+		t.Fatalf("Error refreshing card: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, duskDawn) {
+		t.Errorf("got: %#v want: %#v", got, duskDawn)
+	}
+}
+
+func TestListSetCards(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("q") != "e:akh" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintf(w, `{"object": "list", "has_more": false, "data": [%s]}`, duskDawnJSON)
+	})
+	client, ts, err := setupTestServer("/cards/search", handler)
+	if err != nil {
+		t.Fatalf("Error setting up test server: %v", err)
+	}
+	defer ts.Close()
+
+	set := Set{Code: "akh", SearchURI: ts.URL + "/cards/search?order=set&q=e%3Aakh&unique=prints"}
+	cards, err := client.ListSetCards(context.Background(), set)
+	if err != nil {
+		t.Fatalf("Error listing set cards: %v", err)
+	}
+
+	want := []Card{duskDawn}
+	if !reflect.DeepEqual(cards, want) {
+		t.Errorf("got: %#v want: %#v", cards, want)
+	}
+}
+
+func TestGetSetFor(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"object": "set", "code": "akh", "name": "Amonkhet"}`)
+	})
+	client, ts, err := setupTestServer("/sets/02d1c536-68bc-4208-9b65-7741ef1f9da8", handler)
+	if err != nil {
+		t.Fatalf("Error setting up test server: %v", err)
+	}
+	defer ts.Close()
+
+	card := Card{SetURI: ts.URL + "/sets/02d1c536-68bc-4208-9b65-7741ef1f9da8"}
+	ctx := context.Background()
+	set, err := client.GetSetFor(ctx, card)
+	if err != nil {
+		t.Fatalf("Error getting set: %v", err)
+	}
+
+	want := Set{Code: "akh", Name: "Amonkhet"}
+	if !reflect.DeepEqual(set, want) {
+		t.Errorf("got: %#v want: %#v", set, want)
+	}
+}
+
+func TestFollowForeignURI(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL)
+	})
+	client, ts, err := setupTestServer("/", handler)
+	if err != nil {
+		t.Fatalf("Error setting up test server: %v", err)
+	}
+	defer ts.Close()
+
+	ctx := context.Background()
+	uris := []string{
+		"",
+		"https://example.com/cards/937dbc51-b589-4237-9fce-ea5c757f7c48/rulings",
+		duskDawn.RulingsURI,
+	}
+	for _, uri := range uris {
+		t.Run(uri, func(t *testing.T) {
+			_, err := client.GetRulingsFor(ctx, Card{RulingsURI: uri})
+			if err != ErrForeignURI {
+				t.Errorf("got: %v want: %v", err, ErrForeignURI)
+			}
+		})
+	}
+}
+
+func TestAPIURIBasePath(t *testing.T) {
+	client, err := NewClient(WithBaseURL("https://mirror.example.com/scryfall"))
+	if err != nil {
+		t.Fatalf("Error creating client: %v", err)
+	}
+
+	tests := []struct {
+		uri string
+		err error
+	}{
+		{"https://mirror.example.com/scryfall/cards/abc", nil},
+		{"https://mirror.example.com/cards/abc", ErrForeignURI},
+		{"http://mirror.example.com/scryfall/cards/abc", ErrForeignURI},
+		{"https://mirror.example.com/scryfallx/cards/abc", ErrForeignURI},
+	}
+	for _, test := range tests {
+		t.Run(test.uri, func(t *testing.T) {
+			_, err := client.apiURI(test.uri)
+			if err != test.err {
+				t.Errorf("got: %v want: %v", err, test.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package scryfall
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"net/http"
+
+	// Register the formats Scryfall serves card images in.
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+// ErrImageUnavailable is returned when a card doesn't have an image for the
+// requested face and version.
+var ErrImageUnavailable = errors.New("image unavailable")
+
+// ImageVersion is one of the sizes or crops Scryfall provides card images in.
+type ImageVersion string
+
+const (
+	// ImageVersionSmall is a small full card image. Designed for use as
+	// thumbnail or list icon.
+	ImageVersionSmall ImageVersion = "small"
+
+	// ImageVersionNormal is a medium-sized full card image.
+	ImageVersionNormal ImageVersion = "normal"
+
+	// ImageVersionLarge is a large full card image.
+	ImageVersionLarge ImageVersion = "large"
+
+	// ImageVersionPNG is a transparent, rounded full card PNG.
+	ImageVersionPNG ImageVersion = "png"
+
+	// ImageVersionArtCrop is a rectangular crop of the card's art only.
+	ImageVersionArtCrop ImageVersion = "art_crop"
+
+	// ImageVersionBorderCrop is a full card image with the rounded corners
+	// and the majority of the border cropped off.
+	ImageVersionBorderCrop ImageVersion = "border_crop"
+)
+
+// URI returns the URI of the image in the given version, if there is one.
+func (i ImageURIs) URI(version ImageVersion) (string, bool) {
+	var uri string
+	switch version {
+	case ImageVersionSmall:
+		uri = i.Small
+	case ImageVersionNormal:
+		uri = i.Normal
+	case ImageVersionLarge:
+		uri = i.Large
+	case ImageVersionPNG:
+		uri = i.PNG
+	case ImageVersionArtCrop:
+		uri = i.ArtCrop
+	case ImageVersionBorderCrop:
+		uri = i.BorderCrop
+	}
+
+	return uri, len(uri) != 0
+}
+
+// CardImage is a downloaded card image.
+type CardImage struct {
+	// URI is the URI the image was downloaded from.
+	URI string
+
+	// Version is the version of the image.
+	Version ImageVersion
+
+	// Face is the index of the card face the image belongs to.
+	Face int
+
+	// Status is the state of the card's image when it was downloaded.
+	// Placeholder and low resolution images may be replaced by better
+	// images later. Status is empty if the status is unknown, for example
+	// because the card didn't report one.
+	Status ImageStatus
+
+	// ContentType is the MIME type of the image.
+	ContentType string
+
+	// Data is the encoded image.
+	Data []byte
+}
+
+// Decode decodes the image data.
+func (i CardImage) Decode() (image.Image, error) {
+	img, _, err := image.Decode(bytes.NewReader(i.Data))
+	return img, err
+}
+
+// GetCardImage downloads the image of the card face at index face in the given
+// version. Single-faced cards, split cards, and other cards which print every
+// face on the same side only have an image for face 0.
+//
+// The image is downloaded with the client's User-Agent and rate limiter.
+func (c *Client) GetCardImage(ctx context.Context, card Card, version ImageVersion, face int) (CardImage, error) {
+	imageURIs, ok := card.FaceImageURIs(face)
+	if !ok {
+		return CardImage{}, ErrImageUnavailable
+	}
+	if face > 0 && card.ImageURIs != nil && imageURIs == *card.ImageURIs {
+		return CardImage{}, ErrImageUnavailable
+	}
+	uri, ok := imageURIs.URI(version)
+	if !ok {
+		return CardImage{}, ErrImageUnavailable
+	}
+
+	var status ImageStatus
+	if card.ImageStatus != nil {
+		status = *card.ImageStatus
+	}
+
+	data, contentType, err := c.download(ctx, uri)
+	if err != nil {
+		return CardImage{}, err
+	}
+
+	cardImage := CardImage{
+		URI:         uri,
+		Version:     version,
+		Face:        face,
+		Status:      status,
+		ContentType: contentType,
+		Data:        data,
+	}
+	return cardImage, nil
+}
+
+// download fetches a file which isn't a Scryfall API object, such as an image
+// or a bulk data file, and returns its contents and MIME type.
+func (c *Client) download(ctx context.Context, uri string) ([]byte, string, error) {
+	req, err := http.NewRequest(http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	resp, err := c.do(ctx, req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("downloading %s: unexpected status %s", uri, resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return data, resp.Header.Get("Content-Type"), nil
+}
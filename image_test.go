@@ -0,0 +1,98 @@
+package scryfall
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"net/http"
+	"testing"
+)
+
+func testPNG(t *testing.T, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.RGBA{B: 255, A: 255}), image.Point{}, draw.Src)
+	img.Set(0, 0, color.RGBA{R: 255, A: 255})
+
+	buf := &bytes.Buffer{}
+	err := png.Encode(buf, img)
+	if err != nil {
+		t.Fatalf("Error encoding test image: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestGetCardImage(t *testing.T) {
+	data := testPNG(t, 4, 3)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("User-Agent") != defaultUserAgent {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(data)
+	})
+	client, ts, err := setupTestServer("/png/back/delver.png", handler)
+	if err != nil {
+		t.Fatalf("Error setting up test server: %v", err)
+	}
+	defer ts.Close()
+
+	status := ImageStatusLowres
+	card := delverOfSecrets
+	card.ImageStatus = &status
+	card.CardFaces = append([]CardFace{}, card.CardFaces...)
+	card.CardFaces[1].ImageURIs.PNG = ts.URL + "/png/back/delver.png"
+
+	ctx := context.Background()
+	cardImage, err := client.GetCardImage(ctx, card, ImageVersionPNG, 1)
+	if err != nil {
+		t.Fatalf("Error getting card image: %v", err)
+	}
+
+	if cardImage.Status != ImageStatusLowres || cardImage.Face != 1 || cardImage.ContentType != "image/png" {
+		t.Errorf("unexpected card image: %#v", cardImage)
+	}
+	if !bytes.Equal(cardImage.Data, data) {
+		t.Errorf("got %d bytes, want %d", len(cardImage.Data), len(data))
+	}
+
+	img, err := cardImage.Decode()
+	if err != nil {
+		t.Fatalf("Error decoding card image: %v", err)
+	}
+	if img.Bounds() != image.Rect(0, 0, 4, 3) {
+		t.Errorf("got bounds: %v want: %v", img.Bounds(), image.Rect(0, 0, 4, 3))
+	}
+}
+
+func TestGetCardImageUnavailable(t *testing.T) {
+	client, err := NewClient(WithLimiter(nil))
+	if err != nil {
+		t.Fatalf("Error creating client: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		card    Card
+		version ImageVersion
+		face    int
+	}{
+		{"missing version", delverOfSecrets, ImageVersionArtCrop, 0},
+		{"missing face", delverOfSecrets, ImageVersionNormal, 2},
+		{"shared image", duskDawn, ImageVersionNormal, 1},
+		{"no images", Card{}, ImageVersionNormal, 0},
+	}
+
+	ctx := context.Background()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := client.GetCardImage(ctx, test.card, test.version, test.face)
+			if err != ErrImageUnavailable {
+				t.Errorf("got: %v want: %v", err, ErrImageUnavailable)
+			}
+		})
+	}
+}
@@ -0,0 +1,423 @@
+package scryfall
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/BlueMonday/go-scryfall/internal/fsutil"
+)
+
+// imageStatusQuality orders image statuses from worst to best. Unknown
+// statuses, including the zero value, rank below every known status, so an
+// image cached without a status is replaced once the card reports one, and a
+// card without a status is served any cached image.
+var imageStatusQuality = map[ImageStatus]int{
+	ImageStatusMissing:    1,
+	ImageStatusPlaceholer: 2,
+	ImageStatusLowres:     3,
+	ImageStatusHighres:    4,
+}
+
+// ImageCacheOptions holds the options used to create an image cache.
+type ImageCacheOptions struct {
+	// MaxBytes is the maximum total size of the cached images. The least
+	// recently used images are evicted once the limit is exceeded. A zero
+	// value disables eviction.
+	MaxBytes int64
+}
+
+// ImageCache is an on-disk cache of card images. Images are stored by the
+// SHA-256 hash of their contents so identical images are only stored once, and
+// are looked up by card ID, face and version.
+//
+// A cached image is downloaded again when the card's ImageStatus improves (for
+// example from lowres to highres_scan) or when the image URI changes, which
+// happens when Scryfall replaces an image and updates the timestamp in its
+// query string.
+//
+// An ImageCache is safe for concurrent use, and downloads different images
+// concurrently, but a cache directory must not be shared by several
+// ImageCaches.
+type ImageCache struct {
+	client   *Client
+	dir      string
+	maxBytes int64
+
+	// mu guards the index of the cache directory: the entries in least
+	// recently used order, the number of entries referencing each blob,
+	// and the total size of the blobs.
+	mu      sync.Mutex
+	lru     *list.List
+	entries map[string]*list.Element
+	refs    map[string]int
+	total   int64
+
+	// locks serializes the lookups of each entry, so an image requested
+	// concurrently is only downloaded once.
+	locksMu sync.Mutex
+	locks   map[string]*imageCacheLock
+}
+
+type imageCacheLock struct {
+	mu      sync.Mutex
+	holders int
+}
+
+// imageCacheEntry is the metadata stored for each cached image.
+type imageCacheEntry struct {
+	URI         string       `json:"uri"`
+	Status      ImageStatus  `json:"status"`
+	ContentType string       `json:"content_type"`
+	Hash        string       `json:"hash"`
+	Size        int64        `json:"size"`
+	Version     ImageVersion `json:"version"`
+	Face        int          `json:"face"`
+}
+
+// imageCacheFile is an entry of the index.
+type imageCacheFile struct {
+	path    string
+	entry   imageCacheEntry
+	modTime time.Time
+}
+
+// NewImageCache returns an image cache which stores images in dir and downloads
+// missing images with client. The directory is created if it doesn't exist.
+func NewImageCache(client *Client, dir string, opts ImageCacheOptions) (*ImageCache, error) {
+	for _, subdir := range []string{"entries", "blobs"} {
+		err := os.MkdirAll(filepath.Join(dir, subdir), 0o755)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	c := &ImageCache{
+		client:   client,
+		dir:      dir,
+		maxBytes: opts.MaxBytes,
+		lru:      list.New(),
+		entries:  map[string]*list.Element{},
+		refs:     map[string]int{},
+		locks:    map[string]*imageCacheLock{},
+	}
+	err := c.loadIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// loadIndex reads the entries in the cache directory, ordered by their
+// modification time, and removes the blobs no entry references.
+func (c *ImageCache) loadIndex() error {
+	dirEntries, err := os.ReadDir(filepath.Join(c.dir, "entries"))
+	if err != nil {
+		return err
+	}
+
+	files := []*imageCacheFile{}
+	for _, dirEntry := range dirEntries {
+		if !strings.HasSuffix(dirEntry.Name(), ".json") {
+			continue
+		}
+		info, err := dirEntry.Info()
+		if err != nil {
+			continue
+		}
+		path := filepath.Join(c.dir, "entries", dirEntry.Name())
+		entry, err := readImageCacheEntry(path)
+		if err != nil {
+			continue
+		}
+
+		files = append(files, &imageCacheFile{path: path, entry: entry, modTime: info.ModTime()})
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].modTime.After(files[j].modTime)
+	})
+	for _, file := range files {
+		c.entries[file.path] = c.lru.PushBack(file)
+		if c.refs[file.entry.Hash] == 0 {
+			c.total += file.entry.Size
+		}
+		c.refs[file.entry.Hash]++
+	}
+
+	blobs, err := os.ReadDir(filepath.Join(c.dir, "blobs"))
+	if err != nil {
+		return err
+	}
+	for _, blob := range blobs {
+		if c.refs[blob.Name()] != 0 {
+			continue
+		}
+		err := os.Remove(c.blobPath(blob.Name()))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+
+	return c.evict("")
+}
+
+func (c *ImageCache) entryPath(cardID string, version ImageVersion, face int) string {
+	name := fmt.Sprintf("%s-%d-%s.json", cardID, face, version)
+	return filepath.Join(c.dir, "entries", name)
+}
+
+func (c *ImageCache) blobPath(hash string) string {
+	return filepath.Join(c.dir, "blobs", hash)
+}
+
+// lockEntry locks the entry at path and returns a function which unlocks it.
+func (c *ImageCache) lockEntry(path string) func() {
+	c.locksMu.Lock()
+	lock, ok := c.locks[path]
+	if !ok {
+		lock = &imageCacheLock{}
+		c.locks[path] = lock
+	}
+	lock.holders++
+	c.locksMu.Unlock()
+
+	lock.mu.Lock()
+	return func() {
+		lock.mu.Unlock()
+
+		c.locksMu.Lock()
+		lock.holders--
+		if lock.holders == 0 {
+			delete(c.locks, path)
+		}
+		c.locksMu.Unlock()
+	}
+}
+
+// Get returns the image of the card face at index face in the given version,
+// downloading it if it isn't cached or the cached image is stale.
+func (c *ImageCache) Get(ctx context.Context, card Card, version ImageVersion, face int) (CardImage, error) {
+	if !isSafeFileName(card.ID) {
+		return CardImage{}, fmt.Errorf("invalid card ID %q", card.ID)
+	}
+	imageURIs, ok := card.FaceImageURIs(face)
+	if !ok {
+		return CardImage{}, ErrImageUnavailable
+	}
+	uri, ok := imageURIs.URI(version)
+	if !ok {
+		return CardImage{}, ErrImageUnavailable
+	}
+	var status ImageStatus
+	if card.ImageStatus != nil {
+		status = *card.ImageStatus
+	}
+
+	entryPath := c.entryPath(card.ID, version, face)
+	unlock := c.lockEntry(entryPath)
+	defer unlock()
+
+	entry, ok := c.use(entryPath)
+	if ok && entry.URI == uri && imageStatusQuality[status] <= imageStatusQuality[entry.Status] {
+		data, err := os.ReadFile(c.blobPath(entry.Hash))
+		if err == nil {
+			now := time.Now()
+			err = os.Chtimes(entryPath, now, now)
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				return CardImage{}, err
+			}
+
+			cardImage := CardImage{
+				URI:         entry.URI,
+				Version:     version,
+				Face:        face,
+				Status:      entry.Status,
+				ContentType: entry.ContentType,
+				Data:        data,
+			}
+			return cardImage, nil
+		}
+	}
+
+	cardImage, err := c.client.GetCardImage(ctx, card, version, face)
+	if err != nil {
+		return CardImage{}, err
+	}
+
+	err = c.store(entryPath, cardImage)
+	if err != nil {
+		return CardImage{}, err
+	}
+
+	return cardImage, nil
+}
+
+// use returns the entry at path, if it is cached, and marks it as the most
+// recently used entry.
+func (c *ImageCache) use(path string) (imageCacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[path]
+	if !ok {
+		return imageCacheEntry{}, false
+	}
+	c.lru.MoveToFront(elem)
+
+	return elem.Value.(*imageCacheFile).entry, true
+}
+
+func readImageCacheEntry(path string) (imageCacheEntry, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return imageCacheEntry{}, err
+	}
+
+	entry := imageCacheEntry{}
+	err = json.Unmarshal(b, &entry)
+	if err != nil {
+		return imageCacheEntry{}, err
+	}
+
+	return entry, nil
+}
+
+func (c *ImageCache) store(entryPath string, cardImage CardImage) error {
+	sum := sha256.Sum256(cardImage.Data)
+	hash := hex.EncodeToString(sum[:])
+	entry := imageCacheEntry{
+		URI:         cardImage.URI,
+		Status:      cardImage.Status,
+		ContentType: cardImage.ContentType,
+		Hash:        hash,
+		Size:        int64(len(cardImage.Data)),
+		Version:     cardImage.Version,
+		Face:        cardImage.Face,
+	}
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	// Blobs are shared between entries, so they're written and removed
+	// while holding the index lock.
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	err = fsutil.WriteFileAtomic(c.blobPath(hash), cardImage.Data, 0o644)
+	if err != nil {
+		return err
+	}
+	err = fsutil.WriteFileAtomic(entryPath, b, 0o644)
+	if err != nil {
+		return err
+	}
+
+	// Reference the new blob before releasing the replaced entry, which may
+	// reference the same blob.
+	if c.refs[hash] == 0 {
+		c.total += entry.Size
+	}
+	c.refs[hash]++
+	if elem, ok := c.entries[entryPath]; ok {
+		err = c.remove(elem, false)
+		if err != nil {
+			return err
+		}
+	}
+	c.entries[entryPath] = c.lru.PushFront(&imageCacheFile{path: entryPath, entry: entry, modTime: time.Now()})
+
+	return c.evict(entryPath)
+}
+
+// remove removes the entry from the index, and its blob if no other entry
+// references it. The entry file is only removed if removeFile is true. c.mu
+// must be held.
+func (c *ImageCache) remove(elem *list.Element, removeFile bool) error {
+	file := elem.Value.(*imageCacheFile)
+	c.lru.Remove(elem)
+	delete(c.entries, file.path)
+
+	if removeFile {
+		err := os.Remove(file.path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+
+	c.refs[file.entry.Hash]--
+	if c.refs[file.entry.Hash] > 0 {
+		return nil
+	}
+	delete(c.refs, file.entry.Hash)
+	c.total -= file.entry.Size
+	err := os.Remove(c.blobPath(file.entry.Hash))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// evict removes the least recently used entries until the blobs they
+// reference fit within the size limit. The entry at keep is never evicted, so
+// Get always returns an image it just stored even if the image doesn't fit on
+// its own. c.mu must be held.
+func (c *ImageCache) evict(keep string) error {
+	if c.maxBytes <= 0 {
+		return nil
+	}
+
+	elem := c.lru.Back()
+	for c.total > c.maxBytes && elem != nil {
+		prev := elem.Prev()
+		if elem.Value.(*imageCacheFile).path != keep {
+			err := c.remove(elem, true)
+			if err != nil {
+				return err
+			}
+		}
+		elem = prev
+	}
+
+	return nil
+}
+
+// Warm downloads the images of every face of the given cards in the given
+// version, so later lookups are served from disk. Faces without an image in
+// that version are skipped.
+func (c *ImageCache) Warm(ctx context.Context, cards []Card, version ImageVersion) error {
+	for _, card := range cards {
+		for face := range card.Faces() {
+			_, err := c.Get(ctx, card, version, face)
+			if errors.Is(err, ErrImageUnavailable) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// WarmSet downloads the images of every card in the set in the given version.
+func (c *ImageCache) WarmSet(ctx context.Context, set Set, version ImageVersion) error {
+	cards, err := c.client.ListSetCards(ctx, set)
+	if err != nil {
+		return err
+	}
+
+	return c.Warm(ctx, cards, version)
+}
@@ -0,0 +1,279 @@
+package scryfall
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestImageCache(t *testing.T) {
+	requests := map[string]int{}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		w.Header().Set("Content-Type", "image/jpeg")
+		w.Write([]byte(r.URL.Path + "?" + r.URL.RawQuery))
+	})
+	client, ts, err := setupTestServer("/", handler)
+	if err != nil {
+		t.Fatalf("Error setting up test server: %v", err)
+	}
+	defer ts.Close()
+
+	dir := t.TempDir()
+	cache, err := NewImageCache(client, dir, ImageCacheOptions{})
+	if err != nil {
+		t.Fatalf("Error creating image cache: %v", err)
+	}
+
+	status := ImageStatusLowres
+	card := Card{
+		ID:          "bolt",
+		ImageStatus: &status,
+		ImageURIs:   &ImageURIs{Normal: ts.URL + "/normal/bolt.jpg?1"},
+	}
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		cardImage, err := cache.Get(ctx, card, ImageVersionNormal, 0)
+		if err != nil {
+			t.Fatalf("Error getting image: %v", err)
+		}
+		if string(cardImage.Data) != "/normal/bolt.jpg?1" || cardImage.Status != ImageStatusLowres {
+			t.Errorf("unexpected image: %#v", cardImage)
+		}
+	}
+	if requests["/normal/bolt.jpg"] != 1 {
+		t.Errorf("got %d requests, want 1", requests["/normal/bolt.jpg"])
+	}
+	for _, subdir := range []string{"blobs", "entries"} {
+		paths, err := filepath.Glob(filepath.Join(dir, subdir, "*"))
+		if err != nil || len(paths) != 1 {
+			t.Fatalf("got %v files in %s, want 1: %v", paths, subdir, err)
+		}
+		info, err := os.Stat(paths[0])
+		if err != nil {
+			t.Fatalf("Error reading file mode: %v", err)
+		}
+		if mode := info.Mode().Perm(); mode != 0o644 {
+			t.Errorf("got mode %v for %s want %v", mode, paths[0], os.FileMode(0o644))
+		}
+	}
+
+	highres := ImageStatusHighres
+	card.ImageStatus = &highres
+	cardImage, err := cache.Get(ctx, card, ImageVersionNormal, 0)
+	if err != nil {
+		t.Fatalf("Error getting image: %v", err)
+	}
+	if cardImage.Status != ImageStatusHighres || requests["/normal/bolt.jpg"] != 2 {
+		t.Errorf("image wasn't refreshed when image status improved")
+	}
+
+	card.ImageURIs = &ImageURIs{Normal: ts.URL + "/normal/bolt.jpg?2"}
+	cardImage, err = cache.Get(ctx, card, ImageVersionNormal, 0)
+	if err != nil {
+		t.Fatalf("Error getting image: %v", err)
+	}
+	if string(cardImage.Data) != "/normal/bolt.jpg?2" || requests["/normal/bolt.jpg"] != 3 {
+		t.Errorf("image wasn't refreshed when image URI changed")
+	}
+
+	card.ImageStatus = nil
+	cardImage, err = cache.Get(ctx, card, ImageVersionNormal, 0)
+	if err != nil {
+		t.Fatalf("Error getting image: %v", err)
+	}
+	if cardImage.Status != ImageStatusHighres || requests["/normal/bolt.jpg"] != 3 {
+		t.Errorf("cached image wasn't served for a card without an image status")
+	}
+
+	card.ID = "bolt-unknown"
+	cardImage, err = cache.Get(ctx, card, ImageVersionNormal, 0)
+	if err != nil {
+		t.Fatalf("Error getting image: %v", err)
+	}
+	if len(cardImage.Status) != 0 {
+		t.Errorf("got status: %q want an unknown status", cardImage.Status)
+	}
+	card.ImageStatus = &status
+	cardImage, err = cache.Get(ctx, card, ImageVersionNormal, 0)
+	if err != nil {
+		t.Fatalf("Error getting image: %v", err)
+	}
+	if cardImage.Status != ImageStatusLowres || requests["/normal/bolt.jpg"] != 5 {
+		t.Errorf("image cached without a status wasn't refreshed when the card reported one")
+	}
+}
+
+func TestImageCacheEviction(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.URL.Path))
+	})
+	client, ts, err := setupTestServer("/", handler)
+	if err != nil {
+		t.Fatalf("Error setting up test server: %v", err)
+	}
+	defer ts.Close()
+
+	dir := t.TempDir()
+	cache, err := NewImageCache(client, dir, ImageCacheOptions{MaxBytes: 25})
+	if err != nil {
+		t.Fatalf("Error creating image cache: %v", err)
+	}
+
+	cards := []Card{
+		{ID: "a", ImageURIs: &ImageURIs{Small: ts.URL + "/small/aaaaaaaaaa"}},
+		{ID: "b", ImageURIs: &ImageURIs{Small: ts.URL + "/small/bbbbbbbbbb"}},
+		{ID: "c", ImageURIs: &ImageURIs{Small: ts.URL + "/small/cccccccccc"}},
+	}
+	ctx := context.Background()
+	err = cache.Warm(ctx, cards, ImageVersionSmall)
+	if err != nil {
+		t.Fatalf("Error warming image cache: %v", err)
+	}
+
+	blobs, err := os.ReadDir(filepath.Join(dir, "blobs"))
+	if err != nil {
+		t.Fatalf("Error reading cache directory: %v", err)
+	}
+	if len(blobs) != 1 {
+		t.Errorf("got %d cached images, want 1", len(blobs))
+	}
+
+	_, err = os.Stat(cache.entryPath("c", ImageVersionSmall, 0))
+	if err != nil {
+		t.Errorf("most recently cached image was evicted: %v", err)
+	}
+}
+
+func TestImageCacheConcurrentDownloads(t *testing.T) {
+	var mu sync.Mutex
+	requests := map[string]int{}
+	arrived := 0
+	ready := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests[r.URL.Path]++
+		arrived++
+		if arrived == 2 {
+			close(ready)
+		}
+		mu.Unlock()
+
+		// Each download waits for the other one, so they can only
+		// succeed if they run concurrently.
+		select {
+		case <-ready:
+		case <-time.After(5 * time.Second):
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(r.URL.Path))
+	})
+	client, ts, err := setupTestServer("/", handler, WithLimiter(nil))
+	if err != nil {
+		t.Fatalf("Error setting up test server: %v", err)
+	}
+	defer ts.Close()
+
+	dir := t.TempDir()
+	cache, err := NewImageCache(client, dir, ImageCacheOptions{})
+	if err != nil {
+		t.Fatalf("Error creating image cache: %v", err)
+	}
+
+	cards := []Card{
+		{ID: "a", ImageURIs: &ImageURIs{Small: ts.URL + "/small/a"}},
+		{ID: "b", ImageURIs: &ImageURIs{Small: ts.URL + "/small/b"}},
+		{ID: "a", ImageURIs: &ImageURIs{Small: ts.URL + "/small/a"}},
+	}
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	errs := make([]error, len(cards))
+	for i, card := range cards {
+		wg.Add(1)
+		go func(i int, card Card) {
+			defer wg.Done()
+			_, errs[i] = cache.Get(ctx, card, ImageVersionSmall, 0)
+		}(i, card)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("Error getting image: %v", err)
+		}
+	}
+	if requests["/small/a"] != 1 || requests["/small/b"] != 1 {
+		t.Errorf("got requests: %v want one per image", requests)
+	}
+
+	// A new cache using the same directory serves the stored images.
+	cache, err = NewImageCache(client, dir, ImageCacheOptions{})
+	if err != nil {
+		t.Fatalf("Error creating image cache: %v", err)
+	}
+	cardImage, err := cache.Get(ctx, cards[1], ImageVersionSmall, 0)
+	if err != nil {
+		t.Fatalf("Error getting image: %v", err)
+	}
+	if string(cardImage.Data) != "/small/b" || requests["/small/b"] != 1 {
+		t.Errorf("stored image wasn't served: %#v", cardImage)
+	}
+}
+
+func TestImageCacheSameImageRefreshed(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("image"))
+	})
+	client, ts, err := setupTestServer("/", handler)
+	if err != nil {
+		t.Fatalf("Error setting up test server: %v", err)
+	}
+	defer ts.Close()
+
+	cache, err := NewImageCache(client, t.TempDir(), ImageCacheOptions{})
+	if err != nil {
+		t.Fatalf("Error creating image cache: %v", err)
+	}
+
+	lowres, highres := ImageStatusLowres, ImageStatusHighres
+	card := Card{ID: "bolt", ImageStatus: &lowres, ImageURIs: &ImageURIs{Small: ts.URL + "/small/bolt"}}
+	ctx := context.Background()
+	_, err = cache.Get(ctx, card, ImageVersionSmall, 0)
+	if err != nil {
+		t.Fatalf("Error getting image: %v", err)
+	}
+	card.ImageStatus = &highres
+	_, err = cache.Get(ctx, card, ImageVersionSmall, 0)
+	if err != nil {
+		t.Fatalf("Error getting image: %v", err)
+	}
+
+	_, err = os.Stat(cache.blobPath(fmt.Sprintf("%x", sha256.Sum256([]byte("image")))))
+	if err != nil {
+		t.Errorf("image refreshed with identical data was removed: %v", err)
+	}
+}
+
+func TestImageCacheInvalidCardID(t *testing.T) {
+	client, err := NewClient(WithLimiter(nil))
+	if err != nil {
+		t.Fatalf("Error creating client: %v", err)
+	}
+	cache, err := NewImageCache(client, t.TempDir(), ImageCacheOptions{})
+	if err != nil {
+		t.Fatalf("Error creating image cache: %v", err)
+	}
+
+	card := Card{ID: "../bolt", ImageURIs: &ImageURIs{Small: "https://cards.scryfall.io/small/bolt.jpg"}}
+	_, err = cache.Get(context.Background(), card, ImageVersionSmall, 0)
+	if err == nil {
+		t.Errorf("expected an error for an invalid card ID")
+	}
+}
@@ -0,0 +1,41 @@
+// Package respond writes Scryfall API responses. It is shared by the servers
+// in package mirror and package scryfalltest so both encode responses the
+// same way as api.scryfall.com.
+package respond
+
+import (
+	"encoding/json"
+	"net/http"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+)
+
+// List is a Scryfall list object of any kind of data. Lists of cards are
+// written as scryfall.CardListResponse instead.
+type List struct {
+	TotalCards *int        `json:"total_cards,omitempty"`
+	HasMore    bool        `json:"has_more"`
+	NextPage   *string     `json:"next_page,omitempty"`
+	Data       interface{} `json:"data"`
+}
+
+// MarshalJSON encodes the list in Scryfall's format.
+func (l List) MarshalJSON() ([]byte, error) {
+	type list List
+	return json.Marshal(struct {
+		Object string `json:"object"`
+		list
+	}{Object: "list", list: list(l)})
+}
+
+// JSON writes v as the JSON response with the status code.
+func JSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// Error writes the Scryfall error as the response, with its status code.
+func Error(w http.ResponseWriter, err scryfall.Error) {
+	JSON(w, err.Status, err)
+}
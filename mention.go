@@ -0,0 +1,247 @@
+package scryfall
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+// MentionKind is the kind of response a card mention asks for.
+type MentionKind int
+
+const (
+	// MentionCard is a plain [[name]] mention asking for the card.
+	MentionCard MentionKind = iota
+
+	// MentionImage is a [[!name]] mention asking only for the card's
+	// image.
+	MentionImage
+
+	// MentionRulings is a [[?name]] mention asking for the card's rulings.
+	MentionRulings
+)
+
+// Mention is a card mention in a chat message, such as [[Lightning Bolt]],
+// [[Lightning Bolt|M10]], or [[Lightning Bolt|M10|146]].
+type Mention struct {
+	// Raw is the text of the mention including the brackets.
+	Raw string
+
+	// Kind is the kind of response the mention asks for.
+	Kind MentionKind
+
+	// Name is the card name as written in the mention.
+	Name string
+
+	// Set is the set code of the mention, if any.
+	Set string
+
+	// CollectorNumber is the collector number of the mention, if any. A
+	// collector number is only set if Set is.
+	CollectorNumber string
+}
+
+var mentionRegexp = regexp.MustCompile(`\[\[([^\[\]]+)\]\]`)
+
+// ParseMentions returns the card mentions in text in the order they appear.
+// Mentions without a card name are ignored.
+func ParseMentions(text string) []Mention {
+	mentions := []Mention{}
+	for _, match := range mentionRegexp.FindAllStringSubmatch(text, -1) {
+		mention := Mention{Raw: match[0]}
+
+		body := strings.TrimSpace(match[1])
+		switch {
+		case strings.HasPrefix(body, "!"):
+			mention.Kind = MentionImage
+			body = body[1:]
+		case strings.HasPrefix(body, "?"):
+			mention.Kind = MentionRulings
+			body = body[1:]
+		}
+
+		parts := strings.SplitN(body, "|", 3)
+		mention.Name = strings.TrimSpace(parts[0])
+		if len(parts) > 1 {
+			mention.Set = strings.TrimSpace(parts[1])
+		}
+		if len(parts) > 2 && len(mention.Set) != 0 {
+			mention.CollectorNumber = strings.TrimSpace(parts[2])
+		}
+
+		if len(mention.Name) != 0 {
+			mentions = append(mentions, mention)
+		}
+	}
+
+	return mentions
+}
+
+// identifier returns the collection identifier of the mention.
+func (m Mention) identifier() CardIdentifier {
+	if len(m.CollectorNumber) != 0 {
+		return CardIdentifier{
+			Set:             strings.ToLower(m.Set),
+			CollectorNumber: m.CollectorNumber,
+		}
+	}
+
+	return CardIdentifier{
+		Name: m.Name,
+		Set:  strings.ToLower(m.Set),
+	}
+}
+
+// MentionResult is the card a mention resolved to.
+type MentionResult struct {
+	// Mention is the resolved mention.
+	Mention Mention
+
+	// Card is the card the mention resolved to, if Err is nil.
+	Card Card
+
+	// Fuzzy is true if the mention didn't match a card exactly and was
+	// resolved with a fuzzy name lookup.
+	Fuzzy bool
+
+	// Err is the reason the mention couldn't be resolved, if any.
+	Err error
+}
+
+// ResolveMentionsOptions holds the options used to resolve mentions.
+type ResolveMentionsOptions struct {
+	// Names is used to resolve mentions which don't exactly match a card
+	// name locally. If Names is nil, each of those mentions costs a fuzzy
+	// GetCardByName request.
+	Names *NameIndex
+}
+
+// ResolveMentions resolves the mentions, usually returned by ParseMentions,
+// to cards. Every mention is looked up exactly with a single batched
+// GetCardsByIdentifiers request. Mentions which aren't found are then resolved
+// with a fuzzy name lookup. The results are returned in the order of the
+// mentions; a mention which can't be resolved has its Err set.
+func (c *Client) ResolveMentions(ctx context.Context, mentions []Mention, opts ResolveMentionsOptions) ([]MentionResult, error) {
+	results := make([]MentionResult, len(mentions))
+	identifiers := make([]CardIdentifier, len(mentions))
+	for i, mention := range mentions {
+		results[i].Mention = mention
+		identifiers[i] = mention.identifier()
+	}
+
+	cards, err := c.resolveIdentifiers(ctx, identifiers)
+	if err != nil {
+		return nil, err
+	}
+
+	fuzzy := []int{}
+	for i := range results {
+		card, ok := cards[identifiers[i]]
+		if ok {
+			results[i].Card = card
+		} else {
+			fuzzy = append(fuzzy, i)
+		}
+	}
+	if len(fuzzy) == 0 {
+		return results, nil
+	}
+
+	if opts.Names == nil {
+		for _, i := range fuzzy {
+			mention := results[i].Mention
+			card, err := c.GetCardByName(ctx, mention.Name, false, GetCardByNameOptions{Set: strings.ToLower(mention.Set)})
+			results[i].Card = card
+			results[i].Fuzzy = true
+			results[i].Err = err
+		}
+		return results, nil
+	}
+
+	fuzzyIdentifiers := make([]CardIdentifier, 0, len(fuzzy))
+	for _, i := range fuzzy {
+		name, err := opts.Names.Fuzzy(results[i].Mention.Name)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		identifier := CardIdentifier{Name: name, Set: strings.ToLower(results[i].Mention.Set)}
+		identifiers[i] = identifier
+		fuzzyIdentifiers = append(fuzzyIdentifiers, identifier)
+	}
+
+	cards, err = c.resolveIdentifiers(ctx, fuzzyIdentifiers)
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range fuzzy {
+		if results[i].Err != nil {
+			continue
+		}
+		card, ok := cards[identifiers[i]]
+		if !ok {
+			results[i].Err = ErrNameNotFound
+			continue
+		}
+		results[i].Card = card
+		results[i].Fuzzy = true
+	}
+
+	return results, nil
+}
+
+// resolveIdentifiers looks up the distinct identifiers with batched
+// GetCardsByIdentifiers requests and returns the cards found keyed by
+// identifier.
+func (c *Client) resolveIdentifiers(ctx context.Context, identifiers []CardIdentifier) (map[CardIdentifier]Card, error) {
+	cards := map[CardIdentifier]Card{}
+	unique := []CardIdentifier{}
+	seen := map[CardIdentifier]bool{}
+	for _, identifier := range identifiers {
+		if !seen[identifier] {
+			seen[identifier] = true
+			unique = append(unique, identifier)
+		}
+	}
+	if len(unique) == 0 {
+		return cards, nil
+	}
+
+	response, err := c.getAllCardsByIdentifiers(ctx, unique)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, identifier := range unique {
+		for _, card := range response.Data {
+			if identifierMatches(identifier, card) {
+				cards[identifier] = card
+				break
+			}
+		}
+	}
+
+	return cards, nil
+}
+
+// identifierMatches reports whether the card is the one identified by a name,
+// set, and collector number identifier.
+func identifierMatches(identifier CardIdentifier, card Card) bool {
+	if len(identifier.Set) != 0 && !strings.EqualFold(identifier.Set, card.Set) {
+		return false
+	}
+	if len(identifier.CollectorNumber) != 0 {
+		return identifier.CollectorNumber == card.CollectorNumber
+	}
+
+	name := normalizeName(identifier.Name)
+	if normalizeName(card.Name) == name {
+		return true
+	}
+	for _, face := range card.CardFaces {
+		if normalizeName(face.Name) == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package scryfall
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseMentions(t *testing.T) {
+	text := "Is [[Lightning Bolt]] better than [[ shock | M19 ]]? See [[!Dusk|akh|210]], [[?Mana Drain]] and [[]] [[|m10]]."
+	want := []Mention{
+		{Raw: "[[Lightning Bolt]]", Kind: MentionCard, Name: "Lightning Bolt"},
+		{Raw: "[[ shock | M19 ]]", Kind: MentionCard, Name: "shock", Set: "M19"},
+		{Raw: "[[!Dusk|akh|210]]", Kind: MentionImage, Name: "Dusk", Set: "akh", CollectorNumber: "210"},
+		{Raw: "[[?Mana Drain]]", Kind: MentionRulings, Name: "Mana Drain"},
+	}
+
+	got := ParseMentions(text)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got: %#v want: %#v", got, want)
+	}
+}
+
+func TestResolveMentions(t *testing.T) {
+	cards := []Card{
+		{ID: "bolt", Name: "Lightning Bolt", Set: "m10", CollectorNumber: "146"},
+		{ID: "shock", Name: "Shock", Set: "m19", CollectorNumber: "156"},
+		{ID: "dusk", Name: "Dusk // Dawn", Set: "akh", CollectorNumber: "210"},
+	}
+	mentions := ParseMentions("[[lightning bolt]] [[Shock|M19]] [[!Dusk|akh|210]] [[lightnig bolt]] [[Jace]]")
+
+	collectionRequests := 0
+	fuzzyRequests := 0
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/cards/collection" {
+			collectionHandler(t, cards, &collectionRequests)(w, r)
+			return
+		}
+
+		fuzzyRequests++
+		switch r.URL.Query().Get("fuzzy") {
+		case "lightnig bolt":
+			fmt.Fprintln(w, `{"object": "card", "id": "bolt", "name": "Lightning Bolt", "set": "m10", "collector_number": "146"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintln(w, `{"object": "error", "code": "not_found", "status": 404, "type": "ambiguous", "details": "Too many cards match ambiguous name “jace”. Add more words to refine your search."}`)
+		}
+	})
+	client, ts, err := setupTestServer("/cards/", handler)
+	if err != nil {
+		t.Fatalf("Error setting up test server: %v", err)
+	}
+	defer ts.Close()
+
+	ctx := context.Background()
+	results, err := client.ResolveMentions(ctx, mentions, ResolveMentionsOptions{})
+	if err != nil {
+		t.Fatalf("Error resolving mentions: %v", err)
+	}
+	if collectionRequests != 1 || fuzzyRequests != 2 {
+		t.Errorf("got %d collection and %d fuzzy requests, want 1 and 2", collectionRequests, fuzzyRequests)
+	}
+
+	wantIDs := []string{"bolt", "shock", "dusk", "bolt", ""}
+	wantFuzzy := []bool{false, false, false, true, true}
+	for i, result := range results {
+		if result.Card.ID != wantIDs[i] || result.Fuzzy != wantFuzzy[i] {
+			t.Errorf("mention %s: got card %q fuzzy %t, want %q %t", result.Mention.Raw, result.Card.ID, result.Fuzzy, wantIDs[i], wantFuzzy[i])
+		}
+	}
+	if results[4].Err == nil || !strings.Contains(results[4].Err.Error(), "ambiguous") {
+		t.Errorf("got error %v, want ambiguous name error", results[4].Err)
+	}
+
+	// With a name index, fuzzy lookups are resolved locally and fetched in a
+	// second batch.
+	collectionRequests = 0
+	fuzzyRequests = 0
+	names := NewNameIndex([]string{"Lightning Bolt", "Shock", "Dusk // Dawn", "Jace Beleren", "Jace, the Mind Sculptor"})
+	results, err = client.ResolveMentions(ctx, mentions, ResolveMentionsOptions{Names: names})
+	if err != nil {
+		t.Fatalf("Error resolving mentions: %v", err)
+	}
+	if collectionRequests != 2 || fuzzyRequests != 0 {
+		t.Errorf("got %d collection and %d fuzzy requests, want 2 and 0", collectionRequests, fuzzyRequests)
+	}
+	if results[3].Card.ID != "bolt" || !results[3].Fuzzy {
+		t.Errorf("got: %#v", results[3])
+	}
+	if results[4].Err != ErrAmbiguousName {
+		t.Errorf("got error %v want %v", results[4].Err, ErrAmbiguousName)
+	}
+}
@@ -0,0 +1,196 @@
+package scryfall
+
+import (
+	"context"
+	"fmt"
+
+	qs "github.com/google/go-querystring/query"
+)
+
+// MigrationStrategy describes what happened to a card ID in a migration.
+type MigrationStrategy string
+
+const (
+	// MigrationStrategyMerge indicates the old card ID was merged into
+	// another card. The old ID should be replaced by the new ID.
+	MigrationStrategyMerge MigrationStrategy = "merge"
+
+	// MigrationStrategyDelete indicates the card was deleted without a
+	// replacement, usually because it was a duplicate or never existed.
+	MigrationStrategyDelete MigrationStrategy = "delete"
+)
+
+// Migration describes a change to Scryfall's card database which invalidated
+// a card ID, such as two cards being merged or a card being deleted. Services
+// storing Scryfall IDs can use migrations to update their references.
+type Migration struct {
+	// ID is a unique ID for this migration in Scryfall's database.
+	ID string `json:"id"`
+
+	// URI is a link to this migration object on Scryfall's API.
+	URI string `json:"uri"`
+
+	// CreatedAt is the date this migration was created.
+	CreatedAt Date `json:"created_at"`
+
+	// PerformedAt is the date this migration was performed.
+	PerformedAt Date `json:"performed_at"`
+
+	// MigrationStrategy is what happened to the old card ID.
+	MigrationStrategy MigrationStrategy `json:"migration_strategy"`
+
+	// OldScryfallID is the card ID that was migrated.
+	OldScryfallID string `json:"old_scryfall_id"`
+
+	// NewScryfallID is the card ID that replaces the old ID, if the
+	// migration is a merge.
+	NewScryfallID *string `json:"new_scryfall_id"`
+
+	// Note is a human-readable explanation of the migration, if any.
+	Note *string `json:"note"`
+}
+
+// ListMigrationsOptions holds the options used to list migrations.
+type ListMigrationsOptions struct {
+	// Page is the page number to return. Page numbers start at 1 and the
+	// default is 1.
+	Page int `url:"page,omitempty"`
+}
+
+// MigrationListResponse represents a requested page of migrations.
+type MigrationListResponse struct {
+	// Migrations is a list of the requested migrations.
+	Migrations []Migration `json:"data"`
+
+	// HasMore is true if this List is paginated and there is a page beyond
+	// the current page.
+	HasMore bool `json:"has_more"`
+
+	// NextPage contains a full API URI to next page if there is a page
+	// beyond the current page.
+	NextPage *string `json:"next_page"`
+}
+
+// ListMigrations returns a page of card migrations, most recent first.
+func (c *Client) ListMigrations(ctx context.Context, opts ListMigrationsOptions) (MigrationListResponse, error) {
+	values, err := qs.Values(opts)
+	if err != nil {
+		return MigrationListResponse{}, err
+	}
+	migrationsURL := "migrations"
+	if len(values) != 0 {
+		migrationsURL = fmt.Sprintf("migrations?%s", values.Encode())
+	}
+
+	result := MigrationListResponse{}
+	err = c.get(ctx, migrationsURL, &result)
+	if err != nil {
+		return MigrationListResponse{}, err
+	}
+
+	return result, nil
+}
+
+// ListAllMigrations returns every card migration by following every page of
+// the migrations list. Like the other links in Scryfall objects, each NextPage
+// must belong to the configured base URL.
+func (c *Client) ListAllMigrations(ctx context.Context) ([]Migration, error) {
+	migrations := []Migration{}
+	migrationsURL := "migrations"
+	for {
+		result := MigrationListResponse{}
+		err := c.get(ctx, migrationsURL, &result)
+		if err != nil {
+			return nil, err
+		}
+		migrations = append(migrations, result.Migrations...)
+
+		if !result.HasMore || result.NextPage == nil {
+			return migrations, nil
+		}
+		migrationsURL, err = c.apiURI(*result.NextPage)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// GetMigration returns the migration with the given ID.
+func (c *Client) GetMigration(ctx context.Context, id string) (Migration, error) {
+	migrationURL := fmt.Sprintf("migrations/%s", id)
+	migration := Migration{}
+	err := c.get(ctx, migrationURL, &migration)
+	if err != nil {
+		return Migration{}, err
+	}
+
+	return migration, nil
+}
+
+// MigrationCheck reports which stored card IDs have been migrated.
+type MigrationCheck struct {
+	// Merged maps each merged card ID to the ID that replaces it. When an
+	// ID was merged several times, the replacement is the most recent ID.
+	Merged map[string]string
+
+	// Deleted is the list of card IDs which were deleted without a
+	// replacement.
+	Deleted []string
+}
+
+// checkMigrations reports which of the given card IDs have been merged or
+// deleted according to the migrations.
+func checkMigrations(migrations []Migration, ids []string) MigrationCheck {
+	// Migrations are listed most recent first, so the first migration of an
+	// ID is the one that applies.
+	byOldID := make(map[string]Migration, len(migrations))
+	for _, migration := range migrations {
+		if _, ok := byOldID[migration.OldScryfallID]; !ok {
+			byOldID[migration.OldScryfallID] = migration
+		}
+	}
+
+	check := MigrationCheck{
+		Merged:  map[string]string{},
+		Deleted: []string{},
+	}
+	for _, id := range ids {
+		current := id
+		deleted := false
+		seen := map[string]bool{}
+		for {
+			migration, ok := byOldID[current]
+			if !ok || seen[current] {
+				break
+			}
+			seen[current] = true
+
+			if migration.MigrationStrategy == MigrationStrategyDelete || migration.NewScryfallID == nil {
+				deleted = true
+				break
+			}
+			current = *migration.NewScryfallID
+		}
+
+		switch {
+		case deleted:
+			check.Deleted = append(check.Deleted, id)
+		case current != id:
+			check.Merged[id] = current
+		}
+	}
+
+	return check
+}
+
+// CheckMigrations fetches every card migration and reports which of the given
+// card IDs have been merged or deleted. IDs which haven't been migrated aren't
+// included in the result.
+func (c *Client) CheckMigrations(ctx context.Context, ids []string) (MigrationCheck, error) {
+	migrations, err := c.ListAllMigrations(ctx)
+	if err != nil {
+		return MigrationCheck{}, err
+	}
+
+	return checkMigrations(migrations, ids), nil
+}
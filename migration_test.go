@@ -0,0 +1,79 @@
+package scryfall
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGetMigration(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"object": "migration", "id": "2d7bb6a5-2d54-4e4b-a6e2-2b1a0fbd3e0e", "uri": "https://api.scryfall.com/migrations/2d7bb6a5-2d54-4e4b-a6e2-2b1a0fbd3e0e", "performed_at": "2024-02-02", "migration_strategy": "merge", "old_scryfall_id": "0d2f9f8a-1b1b-4e0c-9a8e-2d6f7e4b7e11", "new_scryfall_id": "5a4f1e2b-6c3d-4b8a-9e7f-1c2d3e4f5a6b", "note": "Duplicate printing", "metadata": {"id": "0d2f9f8a-1b1b-4e0c-9a8e-2d6f7e4b7e11"}}`)
+	})
+	client, ts, err := setupTestServer("/migrations/2d7bb6a5-2d54-4e4b-a6e2-2b1a0fbd3e0e", handler)
+	if err != nil {
+		t.Fatalf("Error setting up test server: %v", err)
+	}
+	defer ts.Close()
+
+	ctx := context.Background()
+	migration, err := client.GetMigration(ctx, "2d7bb6a5-2d54-4e4b-a6e2-2b1a0fbd3e0e")
+	if err != nil {
+		t.Fatalf("Error getting migration: %v", err)
+	}
+
+	want := Migration{
+		ID:                "2d7bb6a5-2d54-4e4b-a6e2-2b1a0fbd3e0e",
+		URI:               "https://api.scryfall.com/migrations/2d7bb6a5-2d54-4e4b-a6e2-2b1a0fbd3e0e",
+		PerformedAt:       Date{Time: time.Date(2024, 2, 2, 0, 0, 0, 0, time.FixedZone("UTC-8", -8*60*60))},
+		MigrationStrategy: MigrationStrategyMerge,
+		OldScryfallID:     "0d2f9f8a-1b1b-4e0c-9a8e-2d6f7e4b7e11",
+		NewScryfallID:     stringPointer("5a4f1e2b-6c3d-4b8a-9e7f-1c2d3e4f5a6b"),
+		Note:              stringPointer("Duplicate printing"),
+	}
+	if !reflect.DeepEqual(migration, want) {
+		t.Errorf("got: %#v want: %#v", migration, want)
+	}
+}
+
+func TestCheckMigrations(t *testing.T) {
+	var serverURL string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprintln(w, `{"object": "list", "has_more": false, "data": [
+				{"object": "migration", "migration_strategy": "merge", "old_scryfall_id": "a", "new_scryfall_id": "b"},
+				{"object": "migration", "migration_strategy": "delete", "old_scryfall_id": "d", "new_scryfall_id": null}
+			]}`)
+			return
+		}
+		fmt.Fprintf(w, `{"object": "list", "has_more": true, "next_page": "%s/migrations?page=2", "data": [
+			{"object": "migration", "migration_strategy": "merge", "old_scryfall_id": "b", "new_scryfall_id": "c"},
+			{"object": "migration", "migration_strategy": "merge", "old_scryfall_id": "e", "new_scryfall_id": "d"},
+			{"object": "migration", "migration_strategy": "merge", "old_scryfall_id": "x", "new_scryfall_id": "y"},
+			{"object": "migration", "migration_strategy": "merge", "old_scryfall_id": "y", "new_scryfall_id": "x"}
+		]}`, serverURL)
+	})
+	client, ts, err := setupTestServer("/migrations", handler)
+	if err != nil {
+		t.Fatalf("Error setting up test server: %v", err)
+	}
+	defer ts.Close()
+	serverURL = ts.URL
+
+	ctx := context.Background()
+	check, err := client.CheckMigrations(ctx, []string{"a", "b", "c", "d", "e", "x"})
+	if err != nil {
+		t.Fatalf("Error checking migrations: %v", err)
+	}
+
+	want := MigrationCheck{
+		Merged:  map[string]string{"a": "c", "b": "c"},
+		Deleted: []string{"d", "e"},
+	}
+	if !reflect.DeepEqual(check, want) {
+		t.Errorf("got: %#v want: %#v", check, want)
+	}
+}
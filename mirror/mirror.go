@@ -0,0 +1,628 @@
+// Package mirror implements a local HTTP server speaking the same REST API as
+// api.scryfall.com, backed by offline data such as Scryfall's bulk data
+// files. Services using go-scryfall can point scryfall.WithBaseURL at a mirror
+// to stop sending requests to Scryfall.
+//
+// The mirror serves the card, set, symbology, catalog, and bulk data
+// endpoints. Card searches support a subset of Scryfall's search syntax, see
+// Server for details.
+package mirror
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+	"github.com/BlueMonday/go-scryfall/internal/respond"
+)
+
+// defaultPageSize is the number of cards in a page of search results, the
+// same as Scryfall's.
+const defaultPageSize = 175
+
+// maxCollectionIdentifiers is the maximum number of identifiers accepted by
+// the cards/collection endpoint.
+const maxCollectionIdentifiers = 75
+
+// Config holds the data served by a mirror. Any of the fields can be left
+// empty, in which case the matching endpoints report that nothing was found.
+type Config struct {
+	// Cards is the store of cards served by the card endpoints, usually
+	// loaded from a card bulk data file.
+	Cards *scryfall.CardStore
+
+	// Sets is the list of sets served by the set endpoints.
+	Sets []scryfall.Set
+
+	// Rulings is the index of rulings served by the card rulings
+	// endpoints, usually loaded from the rulings bulk data file.
+	Rulings *scryfall.RulingsIndex
+
+	// Symbols is the list of card symbols served by the symbology
+	// endpoint.
+	Symbols []scryfall.CardSymbol
+
+	// Catalogs holds the catalogs served by the catalog endpoints.
+	Catalogs scryfall.CatalogSnapshot
+
+	// BulkData is the list of bulk data items served by the bulk data
+	// endpoints.
+	BulkData []scryfall.BulkData
+
+	// PageSize is the number of cards in a page of search results. The
+	// default is 175, like Scryfall.
+	PageSize int
+
+	// BaseURL is the external URL of the mirror, such as
+	// https://scryfall.example.com, used to link to the next page of search
+	// results. If it's empty, links are built from the request's Host
+	// header, using https if the request was received over TLS or has an
+	// X-Forwarded-Proto header of https.
+	BaseURL string
+}
+
+// Server is an http.Handler serving the Scryfall API from offline data.
+//
+// Card searches support name words, quoted names, and the set (s, e),
+// oracleid, lang, type (t), oracle (o), rarity (r), and number (cn) keywords,
+// each of which can be negated with a leading "-". The unique (cards or
+// prints), order (name, released, or set), dir, include_multilingual, and page
+// parameters are supported.
+//
+// A Server must not be modified after it is created, but it is safe for
+// concurrent use.
+type Server struct {
+	config Config
+	cards  []scryfall.Card
+	names  *scryfall.NameIndex
+	sets   *scryfall.SetIndex
+
+	bySetNumber  map[string][]int
+	byMultiverse map[int]int
+	byMTGO       map[int]int
+	byArena      map[int]int
+	byTCGPlayer  map[int]int
+	byCardmarket map[int]int
+	byName       map[string][]int
+}
+
+// NewServer returns a mirror serving the data in config.
+func NewServer(config Config) *Server {
+	if config.Cards == nil {
+		config.Cards = scryfall.NewCardStore(nil)
+	}
+	if config.Rulings == nil {
+		config.Rulings = scryfall.NewRulingsIndex(nil)
+	}
+	if config.PageSize <= 0 {
+		config.PageSize = defaultPageSize
+	}
+
+	s := &Server{
+		config:       config,
+		cards:        config.Cards.Cards(),
+		sets:         scryfall.NewSetIndex(config.Sets),
+		bySetNumber:  map[string][]int{},
+		byMultiverse: map[int]int{},
+		byMTGO:       map[int]int{},
+		byArena:      map[int]int{},
+		byTCGPlayer:  map[int]int{},
+		byCardmarket: map[int]int{},
+		byName:       map[string][]int{},
+	}
+	s.names = scryfall.NewNameIndexFromCards(s.cards)
+
+	for i, card := range s.cards {
+		key := setNumberKey(card.Set, card.CollectorNumber)
+		s.bySetNumber[key] = append(s.bySetNumber[key], i)
+		for _, multiverseID := range card.MultiverseIDs {
+			s.byMultiverse[multiverseID] = i
+		}
+		for _, id := range []*int{card.MTGOID, card.MTGOFoilID} {
+			if id != nil {
+				s.byMTGO[*id] = i
+			}
+		}
+		if card.ArenaID != nil {
+			s.byArena[*card.ArenaID] = i
+		}
+		if card.TCGPlayerID != nil {
+			s.byTCGPlayer[*card.TCGPlayerID] = i
+		}
+		if card.CardMarketID != nil {
+			s.byCardmarket[*card.CardMarketID] = i
+		}
+		s.byName[card.Name] = append(s.byName[card.Name], i)
+	}
+
+	return s
+}
+
+func setNumberKey(set, collectorNumber string) string {
+	return strings.ToLower(set) + "/" + collectorNumber
+}
+
+// ServeHTTP serves a Scryfall API request.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	for i, segment := range segments {
+		unescaped, err := url.PathUnescape(segment)
+		if err == nil {
+			segments[i] = unescaped
+		}
+	}
+
+	if r.Method == http.MethodPost {
+		if len(segments) == 2 && segments[0] == "cards" && segments[1] == "collection" {
+			s.serveCollection(w, r)
+			return
+		}
+		writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "This endpoint only supports GET requests.")
+		return
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "This endpoint only supports GET requests.")
+		return
+	}
+
+	switch segments[0] {
+	case "cards":
+		s.serveCards(w, r, segments[1:])
+	case "sets":
+		s.serveSets(w, segments[1:])
+	case "symbology":
+		if len(segments) == 1 {
+			writeList(w, s.config.Symbols)
+			return
+		}
+		writeNotFound(w)
+	case "catalog":
+		if len(segments) != 2 {
+			writeNotFound(w)
+			return
+		}
+		catalog, ok := s.config.Catalogs.Catalogs[scryfall.CatalogName(segments[1])]
+		if !ok {
+			writeNotFound(w)
+			return
+		}
+		if catalog.Data == nil {
+			catalog.Data = []string{}
+		}
+		catalog.TotalValues = len(catalog.Data)
+		respond.JSON(w, http.StatusOK, catalog)
+	case "bulk-data":
+		s.serveBulkData(w, segments[1:])
+	default:
+		writeNotFound(w)
+	}
+}
+
+func (s *Server) serveCards(w http.ResponseWriter, r *http.Request, segments []string) {
+	if len(segments) == 0 {
+		writeNotFound(w)
+		return
+	}
+
+	rulings := false
+	if len(segments) > 1 && segments[len(segments)-1] == "rulings" {
+		rulings = true
+		segments = segments[:len(segments)-1]
+	}
+
+	var card scryfall.Card
+	var ok bool
+	switch {
+	case len(segments) == 1 && segments[0] == "search" && !rulings:
+		s.serveSearch(w, r)
+		return
+	case len(segments) == 1 && segments[0] == "named" && !rulings:
+		s.serveNamed(w, r)
+		return
+	case len(segments) == 1 && segments[0] == "autocomplete" && !rulings:
+		writeCatalog(w, s.names.Autocomplete(r.URL.Query().Get("q")))
+		return
+	case len(segments) == 1:
+		card, ok = s.config.Cards.Card(segments[0])
+	case len(segments) == 2:
+		var index map[int]int
+		switch segments[0] {
+		case "multiverse":
+			index = s.byMultiverse
+		case "mtgo":
+			index = s.byMTGO
+		case "arena":
+			index = s.byArena
+		case "tcgplayer":
+			index = s.byTCGPlayer
+		case "cardmarket":
+			index = s.byCardmarket
+		}
+		if index != nil {
+			id, err := strconv.Atoi(segments[1])
+			if i, found := index[id]; err == nil && found {
+				card, ok = s.cards[i], true
+			}
+		} else {
+			card, ok = s.bySetAndNumber(segments[0], segments[1], "")
+		}
+	case len(segments) == 3:
+		card, ok = s.bySetAndNumber(segments[0], segments[1], scryfall.Lang(segments[2]))
+	}
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "No card found with the given ID or set code and collector number.")
+		return
+	}
+
+	if rulings {
+		cardRulings := s.config.Rulings.RulingsFor(card)
+		if cardRulings == nil {
+			cardRulings = []scryfall.Ruling{}
+		}
+		writeList(w, cardRulings)
+		return
+	}
+	respond.JSON(w, http.StatusOK, &card)
+}
+
+// bySetAndNumber returns the card with the set code and collector number, in
+// the given language or in English if lang is empty.
+func (s *Server) bySetAndNumber(set, collectorNumber string, lang scryfall.Lang) (scryfall.Card, bool) {
+	if len(lang) == 0 {
+		lang = scryfall.LangEnglish
+	}
+	indexes := s.bySetNumber[setNumberKey(set, collectorNumber)]
+	for _, i := range indexes {
+		if s.cards[i].Lang == lang {
+			return s.cards[i], true
+		}
+	}
+	if lang == scryfall.LangEnglish && len(indexes) != 0 {
+		return s.cards[indexes[0]], true
+	}
+
+	return scryfall.Card{}, false
+}
+
+func (s *Server) serveNamed(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var name string
+	var err error
+	switch {
+	case len(query.Get("exact")) != 0:
+		name, err = s.names.Exact(query.Get("exact"))
+	case len(query.Get("fuzzy")) != 0:
+		name, err = s.names.Fuzzy(query.Get("fuzzy"))
+	default:
+		writeError(w, http.StatusBadRequest, "bad_request", "You must provide either an exact or fuzzy parameter.")
+		return
+	}
+	if err == scryfall.ErrAmbiguousName {
+		writeErrorType(w, http.StatusNotFound, "not_found", "ambiguous", "Too many cards match ambiguous name. Add more words to refine your search.")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusNotFound, "not_found", "No cards found matching the given name.")
+		return
+	}
+
+	card, ok := s.newestPrinting(name, query.Get("set"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "No cards found matching the given name.")
+		return
+	}
+	respond.JSON(w, http.StatusOK, &card)
+}
+
+// newestPrinting returns the most recently released English printing of the
+// card with the given name, limited to set if it isn't empty.
+func (s *Server) newestPrinting(name, set string) (scryfall.Card, bool) {
+	best := -1
+	for _, i := range s.byName[name] {
+		card := s.cards[i]
+		if len(set) != 0 && !strings.EqualFold(card.Set, set) {
+			continue
+		}
+		if best == -1 {
+			best = i
+			continue
+		}
+
+		bestCard := s.cards[best]
+		if (card.Lang == scryfall.LangEnglish) != (bestCard.Lang == scryfall.LangEnglish) {
+			if card.Lang == scryfall.LangEnglish {
+				best = i
+			}
+			continue
+		}
+		if card.ReleasedAt.After(bestCard.ReleasedAt.Time) {
+			best = i
+		}
+	}
+	if best == -1 {
+		return scryfall.Card{}, false
+	}
+
+	return s.cards[best], true
+}
+
+func (s *Server) serveCollection(w http.ResponseWriter, r *http.Request) {
+	request := scryfall.GetCardsByIdentifiersRequest{}
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "The JSON you provided couldn't be parsed.")
+		return
+	}
+	if len(request.Identifiers) > maxCollectionIdentifiers {
+		writeError(w, http.StatusUnprocessableEntity, "validation_error", fmt.Sprintf("Your request exceeded the maximum of %d identifiers.", maxCollectionIdentifiers))
+		return
+	}
+
+	response := scryfall.GetCardsByIdentifiersResponse{
+		NotFound: []scryfall.CardIdentifier{},
+		Data:     []scryfall.Card{},
+	}
+	for _, identifier := range request.Identifiers {
+		card, ok := s.identify(identifier)
+		if !ok {
+			response.NotFound = append(response.NotFound, identifier)
+			continue
+		}
+		response.Data = append(response.Data, card)
+	}
+
+	respond.JSON(w, http.StatusOK, response)
+}
+
+func (s *Server) identify(identifier scryfall.CardIdentifier) (scryfall.Card, bool) {
+	lookup := func(index map[int]int, id int) (scryfall.Card, bool) {
+		i, ok := index[id]
+		if !ok {
+			return scryfall.Card{}, false
+		}
+		return s.cards[i], true
+	}
+
+	switch {
+	case len(identifier.ID) != 0:
+		return s.config.Cards.Card(identifier.ID)
+	case identifier.MTGOID != 0:
+		return lookup(s.byMTGO, identifier.MTGOID)
+	case identifier.MultiverseID != 0:
+		return lookup(s.byMultiverse, identifier.MultiverseID)
+	case len(identifier.CollectorNumber) != 0:
+		return s.bySetAndNumber(identifier.Set, identifier.CollectorNumber, "")
+	case len(identifier.Name) != 0:
+		name, err := s.names.Exact(identifier.Name)
+		if err != nil {
+			return scryfall.Card{}, false
+		}
+		return s.newestPrinting(name, identifier.Set)
+	}
+
+	return scryfall.Card{}, false
+}
+
+func (s *Server) serveSets(w http.ResponseWriter, segments []string) {
+	var set scryfall.Set
+	var ok bool
+	switch {
+	case len(segments) == 0:
+		writeList(w, s.sets.Sets())
+		return
+	case len(segments) == 1:
+		set, ok = s.sets.ByID(segments[0])
+		if !ok {
+			set, ok = s.sets.ByCode(segments[0])
+		}
+	case len(segments) == 2 && segments[0] == "tcgplayer":
+		id, err := strconv.Atoi(segments[1])
+		if err == nil {
+			set, ok = s.sets.ByTCGPlayerID(id)
+		}
+	}
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "No set found with the given code or ID.")
+		return
+	}
+
+	respond.JSON(w, http.StatusOK, &set)
+}
+
+func (s *Server) serveBulkData(w http.ResponseWriter, segments []string) {
+	if len(segments) == 0 {
+		writeList(w, s.config.BulkData)
+		return
+	}
+	if len(segments) == 1 {
+		for _, bulkData := range s.config.BulkData {
+			if bulkData.ID == segments[0] || bulkData.Type == segments[0] {
+				respond.JSON(w, http.StatusOK, &bulkData)
+				return
+			}
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "not_found", "No bulk data found with the given ID or type.")
+}
+
+func (s *Server) serveSearch(w http.ResponseWriter, r *http.Request) {
+	values := r.URL.Query()
+	query, err := parseQuery(values.Get("q"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", err.Error())
+		return
+	}
+	includeMultilingual := values.Get("include_multilingual") == "true"
+
+	matches := []scryfall.Card{}
+	seen := map[string]bool{}
+	for _, card := range s.cards {
+		if !includeMultilingual && !query.hasLang && card.Lang != scryfall.LangEnglish {
+			continue
+		}
+		if !query.matches(card) {
+			continue
+		}
+		if values.Get("unique") != "prints" {
+			key := card.OracleID
+			if len(key) == 0 {
+				key = card.Name
+			}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+		matches = append(matches, card)
+	}
+	if len(matches) == 0 {
+		writeError(w, http.StatusNotFound, "not_found", "Your query didn't match any cards.")
+		return
+	}
+
+	sortCards(matches, values.Get("order"), values.Get("dir"))
+
+	page := 1
+	if len(values.Get("page")) != 0 {
+		page, err = strconv.Atoi(values.Get("page"))
+		if err != nil || page < 1 {
+			writeError(w, http.StatusBadRequest, "bad_request", "The page parameter must be a positive integer.")
+			return
+		}
+	}
+	start := (page - 1) * s.config.PageSize
+	if start >= len(matches) {
+		writeError(w, http.StatusNotFound, "not_found", "The page you requested is beyond the end of the results.")
+		return
+	}
+	end := start + s.config.PageSize
+	if end > len(matches) {
+		end = len(matches)
+	}
+
+	list := scryfall.CardListResponse{
+		Cards:      matches[start:end],
+		HasMore:    end < len(matches),
+		TotalCards: len(matches),
+	}
+	if list.HasMore {
+		values.Set("page", strconv.Itoa(page+1))
+		nextPage := s.requestURL(r, values)
+		list.NextPage = &nextPage
+	}
+	respond.JSON(w, http.StatusOK, list)
+}
+
+// sortCards sorts the cards like the order and dir search parameters.
+func sortCards(cards []scryfall.Card, order, dir string) {
+	less := func(a, b scryfall.Card) bool {
+		return a.Name < b.Name
+	}
+	switch order {
+	case "released":
+		less = func(a, b scryfall.Card) bool {
+			return a.ReleasedAt.Before(b.ReleasedAt.Time)
+		}
+	case "set":
+		less = func(a, b scryfall.Card) bool {
+			if a.Set != b.Set {
+				return a.Set < b.Set
+			}
+			return compareCollectorNumbers(a.CollectorNumber, b.CollectorNumber) < 0
+		}
+	}
+	if dir == "desc" || (order == "released" && dir != "asc") {
+		ascending := less
+		less = func(a, b scryfall.Card) bool {
+			return ascending(b, a)
+		}
+	}
+
+	sort.SliceStable(cards, func(i, j int) bool {
+		return less(cards[i], cards[j])
+	})
+}
+
+// compareCollectorNumbers compares two collector numbers by their numeric
+// prefix first, so 9 sorts before 10 and 10 before 10a, and then as strings.
+// It returns -1, 0, or 1 like strings.Compare.
+func compareCollectorNumbers(a, b string) int {
+	aNumber, aDigits := collectorNumberPrefix(a)
+	bNumber, bDigits := collectorNumberPrefix(b)
+	switch {
+	case aDigits && !bDigits:
+		return -1
+	case !aDigits && bDigits:
+		return 1
+	case aNumber < bNumber:
+		return -1
+	case aNumber > bNumber:
+		return 1
+	}
+
+	return strings.Compare(a, b)
+}
+
+// collectorNumberPrefix returns the number at the start of the collector
+// number, and whether it starts with one.
+func collectorNumberPrefix(collectorNumber string) (int, bool) {
+	i := 0
+	for i < len(collectorNumber) && collectorNumber[i] >= '0' && collectorNumber[i] <= '9' {
+		i++
+	}
+	number, err := strconv.Atoi(collectorNumber[:i])
+	if err != nil {
+		return 0, false
+	}
+
+	return number, true
+}
+
+// requestURL returns the absolute URL of the request with the given query,
+// relative to the configured base URL if there is one.
+func (s *Server) requestURL(r *http.Request, values url.Values) string {
+	if len(s.config.BaseURL) != 0 {
+		return strings.TrimSuffix(s.config.BaseURL, "/") + r.URL.Path + "?" + values.Encode()
+	}
+
+	scheme := "http"
+	if r.TLS != nil || strings.EqualFold(r.Header.Get("X-Forwarded-Proto"), "https") {
+		scheme = "https"
+	}
+	u := url.URL{
+		Scheme:   scheme,
+		Host:     r.Host,
+		Path:     r.URL.Path,
+		RawQuery: values.Encode(),
+	}
+	return u.String()
+}
+
+func writeList(w http.ResponseWriter, data interface{}) {
+	respond.JSON(w, http.StatusOK, respond.List{Data: data})
+}
+
+func writeCatalog(w http.ResponseWriter, data []string) {
+	if data == nil {
+		data = []string{}
+	}
+	respond.JSON(w, http.StatusOK, scryfall.Catalog{TotalValues: len(data), Data: data})
+}
+
+func writeError(w http.ResponseWriter, status int, code, details string) {
+	respond.Error(w, scryfall.Error{Status: status, Code: code, Details: details})
+}
+
+func writeErrorType(w http.ResponseWriter, status int, code, typ, details string) {
+	respond.Error(w, scryfall.Error{Status: status, Code: code, Details: details, Type: &typ})
+}
+
+func writeNotFound(w http.ResponseWriter) {
+	writeError(w, http.StatusNotFound, "not_found", "The requested endpoint doesn't exist.")
+}
@@ -0,0 +1,282 @@
+package mirror
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+)
+
+func stringPointer(v string) *string {
+	return &v
+}
+
+func intPointer(v int) *int {
+	return &v
+}
+
+func date(year int, month time.Month, day int) scryfall.Date {
+	return scryfall.Date{Time: time.Date(year, month, day, 0, 0, 0, 0, time.FixedZone("UTC-8", -8*60*60))}
+}
+
+var testCards = []scryfall.Card{
+	{ID: "bolt-m10", OracleID: "bolt", Name: "Lightning Bolt", Lang: scryfall.LangEnglish, Set: "m10", CollectorNumber: "146", TypeLine: "Instant", OracleText: "Lightning Bolt deals 3 damage to any target.", Rarity: "common", ReleasedAt: date(2009, 7, 17), MultiverseIDs: []int{191089}},
+	{ID: "bolt-2xm", OracleID: "bolt", Name: "Lightning Bolt", Lang: scryfall.LangEnglish, Set: "2xm", CollectorNumber: "129", TypeLine: "Instant", OracleText: "Lightning Bolt deals 3 damage to any target.", Rarity: "uncommon", ReleasedAt: date(2020, 8, 7), TCGPlayerID: intPointer(220000)},
+	{ID: "bolt-2xm-de", OracleID: "bolt", Name: "Lightning Bolt", PrintedName: stringPointer("Blitzschlag"), Lang: scryfall.LangGerman, Set: "2xm", CollectorNumber: "129", TypeLine: "Instant", Rarity: "uncommon", ReleasedAt: date(2020, 8, 7)},
+	{ID: "shock-m19", OracleID: "shock", Name: "Shock", Lang: scryfall.LangEnglish, Set: "m19", CollectorNumber: "156", TypeLine: "Instant", OracleText: "Shock deals 2 damage to any target.", Rarity: "common", ReleasedAt: date(2018, 7, 13)},
+	{ID: "bears-m19", OracleID: "bears", Name: "Grizzly Bears", Lang: scryfall.LangEnglish, Set: "m19", CollectorNumber: "176", TypeLine: "Creature — Bear", Rarity: "common", ReleasedAt: date(2018, 7, 13)},
+}
+
+func setupMirror(t *testing.T, config Config) (*scryfall.Client, func()) {
+	ts := httptest.NewServer(NewServer(config))
+	client, err := scryfall.NewClient(scryfall.WithBaseURL(ts.URL), scryfall.WithLimiter(nil))
+	if err != nil {
+		ts.Close()
+		t.Fatalf("Error creating client: %v", err)
+	}
+
+	return client, ts.Close
+}
+
+func cardIDs(cards []scryfall.Card) []string {
+	ids := []string{}
+	for _, card := range cards {
+		ids = append(ids, card.ID)
+	}
+	return ids
+}
+
+func TestServerCards(t *testing.T) {
+	client, closeServer := setupMirror(t, Config{
+		Cards: scryfall.NewCardStore(testCards),
+		Rulings: scryfall.NewRulingsIndex([]scryfall.Ruling{
+			{OracleID: "bolt", Source: scryfall.SourceWOTC, PublishedAt: date(2009, 10, 1), Comment: "Any target."},
+		}),
+	})
+	defer closeServer()
+
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		get  func() (scryfall.Card, error)
+		want string
+	}{
+		{"id", func() (scryfall.Card, error) { return client.GetCard(ctx, "shock-m19") }, "shock-m19"},
+		{"set and number", func() (scryfall.Card, error) { return client.GetCardBySetCodeAndCollectorNumber(ctx, "2XM", "129") }, "bolt-2xm"},
+		{"set, number, and lang", func() (scryfall.Card, error) {
+			return client.GetCardBySetCodeAndCollectorNumberInLang(ctx, "2xm", "129", scryfall.LangGerman)
+		}, "bolt-2xm-de"},
+		{"multiverse", func() (scryfall.Card, error) { return client.GetCardByMultiverseID(ctx, 191089) }, "bolt-m10"},
+		{"tcgplayer", func() (scryfall.Card, error) { return client.GetCardByTCGPlayerID(ctx, 220000) }, "bolt-2xm"},
+		{"exact name", func() (scryfall.Card, error) {
+			return client.GetCardByName(ctx, "lightning bolt", true, scryfall.GetCardByNameOptions{})
+		}, "bolt-2xm"},
+		{"fuzzy name in set", func() (scryfall.Card, error) {
+			return client.GetCardByName(ctx, "lightnig bolt", false, scryfall.GetCardByNameOptions{Set: "m10"})
+		}, "bolt-m10"},
+		{"printed name", func() (scryfall.Card, error) {
+			return client.GetCardByName(ctx, "Blitzschlag", true, scryfall.GetCardByNameOptions{})
+		}, "bolt-2xm"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			card, err := test.get()
+			if err != nil {
+				t.Fatalf("Error getting card: %v", err)
+			}
+			if card.ID != test.want {
+				t.Errorf("got: %s want: %s", card.ID, test.want)
+			}
+		})
+	}
+
+	_, err := client.GetCard(ctx, "missing")
+	scryfallErr := &scryfall.Error{}
+	if !errors.As(err, &scryfallErr) || scryfallErr.Status != 404 || scryfallErr.Code != "not_found" {
+		t.Errorf("got error %#v, want a not_found Scryfall error", err)
+	}
+
+	rulings, err := client.GetRulings(ctx, "bolt-2xm")
+	if err != nil {
+		t.Fatalf("Error getting rulings: %v", err)
+	}
+	if len(rulings) != 1 || rulings[0].Comment != "Any target." {
+		t.Errorf("got rulings: %#v", rulings)
+	}
+
+	response, err := client.GetCardsByIdentifiers(ctx, []scryfall.CardIdentifier{
+		{ID: "bears-m19"},
+		{Name: "Shock"},
+		{Set: "m10", CollectorNumber: "146"},
+		{Name: "Counterspell"},
+	})
+	if err != nil {
+		t.Fatalf("Error getting cards by identifiers: %v", err)
+	}
+	if want := []string{"bears-m19", "shock-m19", "bolt-m10"}; !reflect.DeepEqual(cardIDs(response.Data), want) {
+		t.Errorf("got: %#v want: %#v", cardIDs(response.Data), want)
+	}
+	if want := []scryfall.CardIdentifier{{Name: "Counterspell"}}; !reflect.DeepEqual(response.NotFound, want) {
+		t.Errorf("got: %#v want: %#v", response.NotFound, want)
+	}
+}
+
+func TestServerSearch(t *testing.T) {
+	client, closeServer := setupMirror(t, Config{
+		Cards:    scryfall.NewCardStore(testCards),
+		PageSize: 2,
+	})
+	defer closeServer()
+
+	ctx := context.Background()
+	tests := []struct {
+		query string
+		opts  scryfall.SearchCardsOptions
+		want  []string
+	}{
+		{"t:instant", scryfall.SearchCardsOptions{}, []string{"bolt-m10", "shock-m19"}},
+		{"t:instant", scryfall.SearchCardsOptions{Page: 2}, []string{}},
+		{"bolt", scryfall.SearchCardsOptions{Unique: scryfall.UniqueModePrints, Order: scryfall.Order("released")}, []string{"bolt-2xm", "bolt-m10"}},
+		{`-t:instant s:m19`, scryfall.SearchCardsOptions{}, []string{"bears-m19"}},
+		{`o:"2 damage"`, scryfall.SearchCardsOptions{}, []string{"shock-m19"}},
+		{"lang:de", scryfall.SearchCardsOptions{}, []string{"bolt-2xm-de"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			result, err := client.SearchCards(ctx, test.query, test.opts)
+			if test.opts.Page == 2 {
+				if err == nil {
+					t.Fatalf("expected an error for a page beyond the results")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Error searching cards: %v", err)
+			}
+			if !reflect.DeepEqual(cardIDs(result.Cards), test.want) {
+				t.Errorf("got: %#v want: %#v", cardIDs(result.Cards), test.want)
+			}
+		})
+	}
+
+	result, err := client.SearchCards(ctx, "r:common", scryfall.SearchCardsOptions{})
+	if err != nil {
+		t.Fatalf("Error searching cards: %v", err)
+	}
+	if result.TotalCards != 3 || !result.HasMore || result.NextPage == nil {
+		t.Errorf("got total %d has more %t next page %v, want 3 true and a next page", result.TotalCards, result.HasMore, result.NextPage)
+	}
+
+	_, err = client.SearchCards(ctx, "pow:3", scryfall.SearchCardsOptions{})
+	scryfallErr := &scryfall.Error{}
+	if !errors.As(err, &scryfallErr) || scryfallErr.Status != 400 {
+		t.Errorf("got error %#v, want a bad request Scryfall error", err)
+	}
+}
+
+func TestSortCardsBySet(t *testing.T) {
+	cards := []scryfall.Card{
+		{ID: "10", Set: "m19", CollectorNumber: "10"},
+		{ID: "9", Set: "m19", CollectorNumber: "9"},
+		{ID: "10a", Set: "m19", CollectorNumber: "10a"},
+		{ID: "s1", Set: "m19", CollectorNumber: "S1"},
+		{ID: "100", Set: "m19", CollectorNumber: "100"},
+		{ID: "m10-200", Set: "m10", CollectorNumber: "200"},
+	}
+
+	sortCards(cards, "set", "")
+	want := []string{"m10-200", "9", "10", "10a", "100", "s1"}
+	if got := cardIDs(cards); !reflect.DeepEqual(got, want) {
+		t.Errorf("got: %#v want: %#v", got, want)
+	}
+}
+
+func TestServerSearchNextPage(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		header  http.Header
+		want    string
+	}{
+		{"host", "", nil, "http://mirror.example.com/cards/search?page=2&q=r%3Acommon"},
+		{"forwarded proto", "", http.Header{"X-Forwarded-Proto": {"https"}}, "https://mirror.example.com/cards/search?page=2&q=r%3Acommon"},
+		{"base URL", "https://scryfall.example.com/", nil, "https://scryfall.example.com/cards/search?page=2&q=r%3Acommon"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := NewServer(Config{Cards: scryfall.NewCardStore(testCards), PageSize: 1, BaseURL: test.baseURL})
+			r := httptest.NewRequest(http.MethodGet, "http://mirror.example.com/cards/search?q=r%3Acommon", nil)
+			for key, values := range test.header {
+				r.Header[key] = values
+			}
+			w := httptest.NewRecorder()
+			s.ServeHTTP(w, r)
+
+			list := scryfall.CardListResponse{}
+			err := json.Unmarshal(w.Body.Bytes(), &list)
+			if err != nil {
+				t.Fatalf("Error decoding search results: %v", err)
+			}
+			if list.NextPage == nil || *list.NextPage != test.want {
+				t.Errorf("got next page %v want %s", list.NextPage, test.want)
+			}
+		})
+	}
+}
+
+func TestServerSetsCatalogsAndBulkData(t *testing.T) {
+	client, closeServer := setupMirror(t, Config{
+		Sets: []scryfall.Set{
+			{ID: "m10-id", Code: "m10", Name: "Magic 2010"},
+			{ID: "m19-id", Code: "m19", Name: "Core Set 2019"},
+		},
+		Catalogs: scryfall.CatalogSnapshot{
+			Catalogs: map[scryfall.CatalogName]scryfall.Catalog{
+				scryfall.CatalogWatermarks: {URI: "https://api.scryfall.com/catalog/watermarks", Data: []string{"abzan", "orzhov"}},
+			},
+		},
+		BulkData: []scryfall.BulkData{{ID: "rulings-id", Type: "rulings", Name: "Rulings"}},
+	})
+	defer closeServer()
+
+	ctx := context.Background()
+	sets, err := client.ListSets(ctx)
+	if err != nil {
+		t.Fatalf("Error listing sets: %v", err)
+	}
+	if len(sets) != 2 {
+		t.Errorf("got %d sets, want 2", len(sets))
+	}
+	set, err := client.GetSet(ctx, "M19")
+	if err != nil {
+		t.Fatalf("Error getting set: %v", err)
+	}
+	if set.ID != "m19-id" {
+		t.Errorf("got set %s want m19-id", set.ID)
+	}
+
+	catalog, err := client.GetWatermarksCatalog(ctx)
+	if err != nil {
+		t.Fatalf("Error getting catalog: %v", err)
+	}
+	if want := (scryfall.Catalog{URI: "https://api.scryfall.com/catalog/watermarks", TotalValues: 2, Data: []string{"abzan", "orzhov"}}); !reflect.DeepEqual(catalog, want) {
+		t.Errorf("got: %#v want: %#v", catalog, want)
+	}
+
+	bulkData, err := client.GetBulkDataByType(ctx, "rulings")
+	if err != nil {
+		t.Fatalf("Error getting bulk data: %v", err)
+	}
+	if bulkData.ID != "rulings-id" {
+		t.Errorf("got bulk data %s want rulings-id", bulkData.ID)
+	}
+}
@@ -0,0 +1,157 @@
+package mirror
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+)
+
+// query is a parsed card search query. A card matches the query if it
+// matches every term.
+type query struct {
+	terms   []term
+	hasLang bool
+}
+
+// term is a single search term such as t:creature or -s:m10.
+type term struct {
+	keyword string
+	value   string
+	negate  bool
+}
+
+// keywordAliases maps every supported search keyword to its canonical form.
+var keywordAliases = map[string]string{
+	"":         "name",
+	"name":     "name",
+	"s":        "set",
+	"e":        "set",
+	"set":      "set",
+	"edition":  "set",
+	"oracleid": "oracleid",
+	"lang":     "lang",
+	"t":        "type",
+	"type":     "type",
+	"o":        "oracle",
+	"oracle":   "oracle",
+	"r":        "rarity",
+	"rarity":   "rarity",
+	"cn":       "number",
+	"number":   "number",
+}
+
+// parseQuery parses the supported subset of Scryfall's search syntax.
+func parseQuery(s string) (query, error) {
+	q := query{}
+	for _, token := range tokenize(s) {
+		t := term{}
+		if strings.HasPrefix(token, "-") && len(token) > 1 {
+			t.negate = true
+			token = token[1:]
+		}
+
+		keyword := ""
+		value := token
+		if i := strings.IndexAny(token, ":="); i > 0 && !strings.HasPrefix(token, `"`) {
+			keyword = strings.ToLower(token[:i])
+			value = token[i+1:]
+		}
+		canonical, ok := keywordAliases[keyword]
+		if !ok {
+			return query{}, fmt.Errorf("the keyword %q isn't supported by this mirror", keyword)
+		}
+		t.keyword = canonical
+		t.value = strings.ToLower(strings.Trim(value, `"`))
+		if len(t.value) == 0 {
+			continue
+		}
+		if t.keyword == "lang" {
+			q.hasLang = true
+		}
+
+		q.terms = append(q.terms, t)
+	}
+	if len(q.terms) == 0 {
+		return query{}, errors.New("the query is empty")
+	}
+
+	return q, nil
+}
+
+// tokenize splits a query on whitespace outside of double quotes.
+func tokenize(s string) []string {
+	tokens := []string{}
+	var b strings.Builder
+	quoted := false
+	for _, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			b.WriteRune(r)
+		case unicode.IsSpace(r) && !quoted:
+			if b.Len() != 0 {
+				tokens = append(tokens, b.String())
+				b.Reset()
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+	if b.Len() != 0 {
+		tokens = append(tokens, b.String())
+	}
+
+	return tokens
+}
+
+func (q query) matches(card scryfall.Card) bool {
+	for _, t := range q.terms {
+		if t.matches(card) == t.negate {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (t term) matches(card scryfall.Card) bool {
+	switch t.keyword {
+	case "name":
+		return strings.Contains(strings.ToLower(card.Name), t.value)
+	case "set":
+		return strings.ToLower(card.Set) == t.value
+	case "oracleid":
+		if card.OracleID == t.value {
+			return true
+		}
+		for _, face := range card.CardFaces {
+			if face.OracleID != nil && *face.OracleID == t.value {
+				return true
+			}
+		}
+		return false
+	case "lang":
+		return t.value == "any" || string(card.Lang) == t.value
+	case "type":
+		return strings.Contains(strings.ToLower(card.TypeLine), t.value)
+	case "oracle":
+		if strings.Contains(strings.ToLower(card.OracleText), t.value) {
+			return true
+		}
+		for _, face := range card.CardFaces {
+			if face.OracleText != nil && strings.Contains(strings.ToLower(*face.OracleText), t.value) {
+				return true
+			}
+		}
+		return false
+	case "rarity":
+		return strings.HasPrefix(string(card.Rarity), t.value)
+	case "number":
+		return strings.ToLower(card.CollectorNumber) == t.value
+	}
+
+	return false
+}
@@ -0,0 +1,331 @@
+package scryfall
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// maxAutocompletions is the maximum number of names returned by autocomplete,
+// matching Scryfall's autocomplete endpoint.
+const maxAutocompletions = 20
+
+var (
+	// ErrNameNotFound is returned when no card name matches a lookup.
+	ErrNameNotFound = errors.New("no card name matched")
+
+	// ErrAmbiguousName is returned when more than one card name matches a
+	// fuzzy lookup.
+	ErrAmbiguousName = errors.New("more than one card name matched")
+)
+
+// NameIndex is an in-memory index of card names which answers autocomplete
+// and fuzzy name lookups locally so they don't cost an API request. Names are
+// matched ignoring case, accents, and punctuation. Every lookup returns the
+// card's canonical English name, even when it matched one of the card's
+// aliases such as a face name, a flavor name, or a printed name in another
+// language.
+//
+// A NameIndex must not be modified after it is created, but it is safe for
+// concurrent use.
+type NameIndex struct {
+	// names maps the normalized form of every name and alias to the
+	// canonical names it refers to.
+	names map[string][]string
+
+	// keys holds the keys of names in sorted order.
+	keys []string
+}
+
+// NewNameIndex returns a NameIndex containing the given canonical card names,
+// such as the values of GetCardNamesCatalog. The faces of multi-face names
+// like "Dusk // Dawn" can also be looked up on their own.
+func NewNameIndex(names []string) *NameIndex {
+	idx := &NameIndex{names: map[string][]string{}}
+	for _, name := range names {
+		idx.add(name, name)
+		faces := strings.Split(name, " // ")
+		if len(faces) > 1 {
+			for _, face := range faces {
+				idx.add(face, name)
+			}
+		}
+	}
+	idx.sortKeys()
+
+	return idx
+}
+
+// NewNameIndexFromCards returns a NameIndex containing the names of the given
+// cards, such as the cards of a CardStore. In addition to the card names, the
+// face names, flavor names, and printed names of the cards are indexed, so
+// loading a bulk data file containing every language makes the index match
+// names printed in other languages.
+func NewNameIndexFromCards(cards []Card) *NameIndex {
+	idx := &NameIndex{names: map[string][]string{}}
+	for _, card := range cards {
+		idx.add(card.Name, card.Name)
+		if card.FlavorName != nil {
+			idx.add(*card.FlavorName, card.Name)
+		}
+		if card.PrintedName != nil {
+			idx.add(*card.PrintedName, card.Name)
+		}
+		for _, face := range card.CardFaces {
+			idx.add(face.Name, card.Name)
+			if face.PrintedName != nil {
+				idx.add(*face.PrintedName, card.Name)
+			}
+		}
+	}
+	idx.sortKeys()
+
+	return idx
+}
+
+func (idx *NameIndex) add(alias, name string) {
+	key := normalizeName(alias)
+	if len(key) == 0 {
+		return
+	}
+
+	for _, existing := range idx.names[key] {
+		if existing == name {
+			return
+		}
+	}
+	idx.names[key] = append(idx.names[key], name)
+}
+
+func (idx *NameIndex) sortKeys() {
+	idx.keys = make([]string, 0, len(idx.names))
+	for key := range idx.names {
+		idx.keys = append(idx.keys, key)
+	}
+	sort.Strings(idx.keys)
+}
+
+// Len returns the number of distinct names and aliases in the index.
+func (idx *NameIndex) Len() int {
+	return len(idx.keys)
+}
+
+// Autocomplete returns up to 20 card names that could be autocompletions of
+// s. Names starting with s are returned first, followed by names with a word
+// starting with s. Like Scryfall's autocomplete endpoint, no names are
+// returned if s is shorter than 2 characters.
+func (idx *NameIndex) Autocomplete(s string) []string {
+	query := normalizeName(s)
+	completions := []string{}
+	if len([]rune(query)) < 2 {
+		return completions
+	}
+
+	seen := map[string]bool{}
+	collect := func(key string) bool {
+		for _, name := range idx.names[key] {
+			if !seen[name] {
+				seen[name] = true
+				completions = append(completions, name)
+			}
+			if len(completions) == maxAutocompletions {
+				return false
+			}
+		}
+		return true
+	}
+
+	for i := sort.SearchStrings(idx.keys, query); i < len(idx.keys) && strings.HasPrefix(idx.keys[i], query); i++ {
+		if !collect(idx.keys[i]) {
+			return completions
+		}
+	}
+	for _, key := range idx.keys {
+		if !strings.HasPrefix(key, query) && strings.Contains(key, " "+query) {
+			if !collect(key) {
+				return completions
+			}
+		}
+	}
+
+	return completions
+}
+
+// Exact returns the canonical name of the card whose name or alias matches
+// name, ignoring case, accents, and punctuation. It returns ErrAmbiguousName if
+// the alias belongs to more than one card.
+func (idx *NameIndex) Exact(name string) (string, error) {
+	names, ok := idx.names[normalizeName(name)]
+	if !ok {
+		return "", ErrNameNotFound
+	}
+	if len(names) > 1 {
+		return "", ErrAmbiguousName
+	}
+
+	return names[0], nil
+}
+
+// Fuzzy returns the canonical name of the card best matching name, in the
+// spirit of the fuzzy mode of GetCardByName. An exact match is preferred,
+// followed by names whose words start with the words of name in order, such
+// as "jac bel" for "Jace Beleren", followed by names a few typos away from
+// name. ErrAmbiguousName is returned if several cards match equally well and
+// ErrNameNotFound if none do.
+func (idx *NameIndex) Fuzzy(name string) (string, error) {
+	query := normalizeName(name)
+	if len(query) == 0 {
+		return "", ErrNameNotFound
+	}
+
+	if names, ok := idx.names[query]; ok {
+		if len(names) > 1 {
+			return "", ErrAmbiguousName
+		}
+		return names[0], nil
+	}
+
+	queryWords := strings.Fields(query)
+	matches := map[string]bool{}
+	for _, key := range idx.keys {
+		if wordPrefixesMatch(queryWords, strings.Fields(key)) {
+			for _, name := range idx.names[key] {
+				matches[name] = true
+			}
+		}
+	}
+	if match, err := singleMatch(matches); err != ErrNameNotFound {
+		return match, err
+	}
+
+	queryRunes := []rune(query)
+	best := len(queryRunes)/4 + 1
+	for _, key := range idx.keys {
+		keyRunes := []rune(key)
+		if abs(len(keyRunes)-len(queryRunes)) > best {
+			continue
+		}
+		distance := levenshtein(queryRunes, keyRunes)
+		if distance > best {
+			continue
+		}
+		if distance < best {
+			best = distance
+			matches = map[string]bool{}
+		}
+		for _, name := range idx.names[key] {
+			matches[name] = true
+		}
+	}
+
+	return singleMatch(matches)
+}
+
+// singleMatch returns the only name in matches.
+func singleMatch(matches map[string]bool) (string, error) {
+	switch len(matches) {
+	case 0:
+		return "", ErrNameNotFound
+	case 1:
+		for name := range matches {
+			return name, nil
+		}
+	}
+
+	return "", ErrAmbiguousName
+}
+
+// wordPrefixesMatch reports whether every query word is a prefix of a word in
+// words, in order.
+func wordPrefixesMatch(queryWords, words []string) bool {
+	i := 0
+	for _, word := range words {
+		if i == len(queryWords) {
+			break
+		}
+		if strings.HasPrefix(word, queryWords[i]) {
+			i++
+		}
+	}
+
+	return i == len(queryWords)
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = previous[j-1] + cost
+			if previous[j]+1 < current[j] {
+				current[j] = previous[j] + 1
+			}
+			if current[j-1]+1 < current[j] {
+				current[j] = current[j-1] + 1
+			}
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// accentFolds maps accented Latin letters found in card names to their
+// unaccented forms.
+var accentFolds = map[rune]string{
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'æ': "ae",
+	'ç': "c",
+	'è': "e", 'é': "e", 'ê': "e", 'ë': "e",
+	'ì': "i", 'í': "i", 'î': "i", 'ï': "i",
+	'ñ': "n",
+	'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ö': "o", 'ø': "o",
+	'ù': "u", 'ú': "u", 'û': "u", 'ü': "u",
+	'ý': "y", 'ÿ': "y",
+	'ß': "ss",
+}
+
+// normalizeName lowercases a name, folds accents, drops apostrophes, and
+// replaces other punctuation with single spaces, so "Lim-Dûl's Vault" becomes
+// "lim duls vault".
+func normalizeName(name string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r == '\'' || r == '’':
+			continue
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if space && b.Len() != 0 {
+				b.WriteByte(' ')
+			}
+			space = false
+			if fold, ok := accentFolds[r]; ok {
+				b.WriteString(fold)
+			} else {
+				b.WriteRune(r)
+			}
+		default:
+			space = true
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,109 @@
+package scryfall
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+var testNames = []string{
+	"Jace Beleren",
+	"Jace, the Mind Sculptor",
+	"Lim-Dûl's Vault",
+	"Lightning Bolt",
+	"Lightning Helix",
+	"Dusk // Dawn",
+	"Thalia, Guardian of Thraben",
+	"Lethal Sting",
+}
+
+func TestNameIndexAutocomplete(t *testing.T) {
+	names := []string{}
+	for i := 0; i < 25; i++ {
+		names = append(names, fmt.Sprintf("Thallid %02d", i))
+	}
+	idx := NewNameIndex(append(names, testNames...))
+
+	tests := []struct {
+		in  string
+		out []string
+	}{
+		{"l", []string{}},
+		{"LIGHTNING", []string{"Lightning Bolt", "Lightning Helix"}},
+		{"lim dul", []string{"Lim-Dûl's Vault"}},
+		{"thal", append([]string{"Thalia, Guardian of Thraben"}, names[:19]...)},
+		{"sting", []string{"Lethal Sting"}},
+		{"daw", []string{"Dusk // Dawn"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.in, func(t *testing.T) {
+			got := idx.Autocomplete(test.in)
+			if !reflect.DeepEqual(got, test.out) {
+				t.Errorf("got: %#v want: %#v", got, test.out)
+			}
+		})
+	}
+}
+
+func TestNameIndexFuzzy(t *testing.T) {
+	idx := NewNameIndex(testNames)
+
+	tests := []struct {
+		in  string
+		out string
+		err error
+	}{
+		{"lim duls vault", "Lim-Dûl's Vault", nil},
+		{"Dawn", "Dusk // Dawn", nil},
+		{"jac bel", "Jace Beleren", nil},
+		{"jace", "", ErrAmbiguousName},
+		{"lightning", "", ErrAmbiguousName},
+		{"Lightnig Bolt", "Lightning Bolt", nil},
+		{"thalia gaurdian of thraben", "Thalia, Guardian of Thraben", nil},
+		{"Counterspell", "", ErrNameNotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.in, func(t *testing.T) {
+			got, err := idx.Fuzzy(test.in)
+			if err != test.err {
+				t.Fatalf("got error %v want %v", err, test.err)
+			}
+			if got != test.out {
+				t.Errorf("got: %s want: %s", got, test.out)
+			}
+		})
+	}
+}
+
+func TestNewNameIndexFromCards(t *testing.T) {
+	cards := []Card{
+		{Name: "Lightning Bolt", PrintedName: stringPointer("Blitzschlag")},
+		{Name: "Godzilla, King of the Monsters", FlavorName: stringPointer("Zilortha, Strength Incarnate")},
+		{Name: "Delver of Secrets // Insectile Aberration", CardFaces: []CardFace{{Name: "Delver of Secrets"}, {Name: "Insectile Aberration", PrintedName: stringPointer("Aberración insectil")}}},
+	}
+	idx := NewNameIndexFromCards(cards)
+
+	tests := []struct {
+		in  string
+		out string
+	}{
+		{"blitzschlag", "Lightning Bolt"},
+		{"Zilortha, Strength Incarnate", "Godzilla, King of the Monsters"},
+		{"aberracion insectil", "Delver of Secrets // Insectile Aberration"},
+		{"Delver of Secrets", "Delver of Secrets // Insectile Aberration"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.in, func(t *testing.T) {
+			got, err := idx.Exact(test.in)
+			if err != nil {
+				t.Fatalf("Error looking up name: %v", err)
+			}
+			if got != test.out {
+				t.Errorf("got: %s want: %s", got, test.out)
+			}
+		})
+	}
+}
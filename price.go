@@ -0,0 +1,252 @@
+package scryfall
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrCurrencyMismatch is returned when performing arithmetic on prices in
+// different currencies.
+var ErrCurrencyMismatch = errors.New("currency mismatch")
+
+// Currency is a currency Scryfall reports card prices in.
+type Currency string
+
+const (
+	// CurrencyUSD is the US dollar.
+	CurrencyUSD Currency = "usd"
+
+	// CurrencyEUR is the Euro.
+	CurrencyEUR Currency = "eur"
+
+	// CurrencyTix is the Magic Online event ticket.
+	CurrencyTix Currency = "tix"
+)
+
+// Price is a decimal amount of money in a single currency. Scryfall reports
+// prices with two decimal places, so the amount is stored in hundredths of the
+// currency unit to avoid floating point rounding errors.
+//
+// A Price may be missing, which means Scryfall has no price for the card. A
+// missing price is distinct from a price of zero.
+type Price struct {
+	// Currency is the currency of the price.
+	Currency Currency
+
+	// Cents is the price amount in hundredths of the currency unit.
+	Cents int64
+
+	// Valid is true if the price is known. A price which isn't valid is
+	// missing.
+	Valid bool
+}
+
+// NewPrice returns a valid price for the amount in hundredths of the currency
+// unit.
+func NewPrice(currency Currency, cents int64) Price {
+	return Price{Currency: currency, Cents: cents, Valid: true}
+}
+
+// ParsePrice parses a Scryfall price string such as "0.35". An empty string
+// results in a missing price, while a malformed string results in an error.
+func ParsePrice(currency Currency, s string) (Price, error) {
+	if len(s) == 0 {
+		return Price{Currency: currency}, nil
+	}
+
+	whole, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		whole, frac = s[:i], s[i+1:]
+	}
+	if len(whole) == 0 || len(frac) > 2 || strings.HasPrefix(whole, "-") || strings.HasPrefix(whole, "+") {
+		return Price{}, fmt.Errorf("invalid price %q", s)
+	}
+	for len(frac) < 2 {
+		frac += "0"
+	}
+
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return Price{}, fmt.Errorf("invalid price %q: %w", s, err)
+	}
+	cents, err := strconv.ParseInt(frac, 10, 64)
+	if err != nil || frac[0] == '+' || frac[0] == '-' {
+		return Price{}, fmt.Errorf("invalid price %q", s)
+	}
+
+	return NewPrice(currency, units*100+cents), nil
+}
+
+// String returns the price amount formatted like Scryfall formats prices, or
+// an empty string if the price is missing.
+func (p Price) String() string {
+	if !p.Valid {
+		return ""
+	}
+
+	sign := ""
+	cents := p.Cents
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// Float64 returns the price amount in whole currency units. Float64 should
+// only be used for display purposes, use Cents for calculations.
+func (p Price) Float64() float64 {
+	return float64(p.Cents) / 100
+}
+
+// Add returns the sum of two prices in the same currency. A missing price is
+// treated as zero, and the sum is only missing if both prices are missing.
+func (p Price) Add(q Price) (Price, error) {
+	if p.Currency != q.Currency {
+		return Price{}, ErrCurrencyMismatch
+	}
+
+	return Price{
+		Currency: p.Currency,
+		Cents:    p.Cents + q.Cents,
+		Valid:    p.Valid || q.Valid,
+	}, nil
+}
+
+// Mul returns the price multiplied by the quantity n. Mul is useful for
+// calculating the price of several copies of the same card.
+func (p Price) Mul(n int) Price {
+	return Price{
+		Currency: p.Currency,
+		Cents:    p.Cents * int64(n),
+		Valid:    p.Valid,
+	}
+}
+
+// Cmp compares two prices in the same currency and returns -1 if p is less
+// than q, 0 if they are equal, and +1 if p is greater than q. Missing prices
+// are greater than every known price, mirroring Scryfall's "null last" sort
+// order.
+func (p Price) Cmp(q Price) int {
+	switch {
+	case !p.Valid && !q.Valid:
+		return 0
+	case !p.Valid:
+		return 1
+	case !q.Valid:
+		return -1
+	case p.Cents < q.Cents:
+		return -1
+	case p.Cents > q.Cents:
+		return 1
+	}
+	return 0
+}
+
+// SumPrices returns the sum of prices in the given currency. Missing prices
+// are skipped, so the result is only missing if every price is missing.
+func SumPrices(currency Currency, prices ...Price) (Price, error) {
+	total := Price{Currency: currency}
+	for _, p := range prices {
+		var err error
+		total, err = total.Add(p)
+		if err != nil {
+			return Price{}, err
+		}
+	}
+
+	return total, nil
+}
+
+// PriceTotals accumulates prices across several currencies, for example to
+// calculate the value of a deck or collection.
+type PriceTotals map[Currency]Price
+
+// Add adds the price to the total for its currency.
+func (t PriceTotals) Add(p Price) {
+	total := t[p.Currency]
+	total.Currency = p.Currency
+	total, _ = total.Add(p)
+	t[p.Currency] = total
+}
+
+// AddPrices adds every known price in prices for the given finish, multiplied
+// by the quantity n.
+func (t PriceTotals) AddPrices(prices Prices, finish Finish, n int) error {
+	for _, currency := range []Currency{CurrencyUSD, CurrencyEUR, CurrencyTix} {
+		p, err := prices.Price(currency, finish)
+		if err != nil {
+			return err
+		}
+		if p.Valid {
+			t.Add(p.Mul(n))
+		}
+	}
+
+	return nil
+}
+
+// Price returns the price of the card in the given currency and finish. The
+// price is missing if Scryfall doesn't track prices for that combination. Tix
+// prices are only tracked for the nonfoil finish.
+func (p Prices) Price(currency Currency, finish Finish) (Price, error) {
+	var s string
+	switch currency {
+	case CurrencyUSD:
+		switch finish {
+		case FinishNonFoil:
+			s = p.USD
+		case FinishFoil:
+			s = p.USDFoil
+		case FinishEtched:
+			s = p.USDEtched
+		}
+	case CurrencyEUR:
+		switch finish {
+		case FinishNonFoil:
+			s = p.EUR
+		case FinishFoil:
+			s = p.EURFoil
+		}
+	case CurrencyTix:
+		if finish == FinishNonFoil {
+			s = p.Tix
+		}
+	default:
+		return Price{}, fmt.Errorf("unknown currency %q", currency)
+	}
+
+	return ParsePrice(currency, s)
+}
+
+// USDPrice returns the nonfoil price of the card in US dollars.
+func (p Prices) USDPrice() (Price, error) {
+	return ParsePrice(CurrencyUSD, p.USD)
+}
+
+// USDFoilPrice returns the foil price of the card in US dollars.
+func (p Prices) USDFoilPrice() (Price, error) {
+	return ParsePrice(CurrencyUSD, p.USDFoil)
+}
+
+// USDEtchedPrice returns the etched price of the card in US dollars.
+func (p Prices) USDEtchedPrice() (Price, error) {
+	return ParsePrice(CurrencyUSD, p.USDEtched)
+}
+
+// EURPrice returns the nonfoil price of the card in Euros.
+func (p Prices) EURPrice() (Price, error) {
+	return ParsePrice(CurrencyEUR, p.EUR)
+}
+
+// EURFoilPrice returns the foil price of the card in Euros.
+func (p Prices) EURFoilPrice() (Price, error) {
+	return ParsePrice(CurrencyEUR, p.EURFoil)
+}
+
+// TixPrice returns the price of the card in MTGO event tickets.
+func (p Prices) TixPrice() (Price, error) {
+	return ParsePrice(CurrencyTix, p.Tix)
+}
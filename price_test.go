@@ -0,0 +1,178 @@
+package scryfall
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePrice(t *testing.T) {
+	tests := []struct {
+		in      string
+		out     Price
+		wantErr bool
+	}{
+		{"", Price{Currency: CurrencyUSD}, false},
+		{"0.35", NewPrice(CurrencyUSD, 35), false},
+		{"0.00", NewPrice(CurrencyUSD, 0), false},
+		{"1234.5", NewPrice(CurrencyUSD, 123450), false},
+		{"12", NewPrice(CurrencyUSD, 1200), false},
+		{"abc", Price{}, true},
+		{"1.234", Price{}, true},
+		{"-1.00", Price{}, true},
+		{"1.-5", Price{}, true},
+		{".50", Price{}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.in, func(t *testing.T) {
+			got, err := ParsePrice(CurrencyUSD, test.in)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected error parsing %q, got %#v", test.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error parsing price: %v", err)
+			}
+			if !reflect.DeepEqual(got, test.out) {
+				t.Errorf("got: %#v want: %#v", got, test.out)
+			}
+		})
+	}
+}
+
+func TestPriceString(t *testing.T) {
+	tests := []struct {
+		in  Price
+		out string
+	}{
+		{Price{Currency: CurrencyEUR}, ""},
+		{NewPrice(CurrencyEUR, 5), "0.05"},
+		{NewPrice(CurrencyEUR, 123456), "1234.56"},
+		{NewPrice(CurrencyEUR, -150), "-1.50"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.out, func(t *testing.T) {
+			if got := test.in.String(); got != test.out {
+				t.Errorf("got: %s want: %s", got, test.out)
+			}
+		})
+	}
+}
+
+func TestPriceAdd(t *testing.T) {
+	got, err := NewPrice(CurrencyUSD, 35).Add(Price{Currency: CurrencyUSD})
+	if err != nil {
+		t.Fatalf("Unexpected error adding prices: %v", err)
+	}
+	if want := NewPrice(CurrencyUSD, 35); got != want {
+		t.Errorf("got: %#v want: %#v", got, want)
+	}
+
+	got, err = Price{Currency: CurrencyUSD}.Add(Price{Currency: CurrencyUSD})
+	if err != nil {
+		t.Fatalf("Unexpected error adding prices: %v", err)
+	}
+	if got.Valid {
+		t.Errorf("sum of missing prices should be missing, got: %#v", got)
+	}
+
+	_, err = NewPrice(CurrencyUSD, 35).Add(NewPrice(CurrencyEUR, 35))
+	if err != ErrCurrencyMismatch {
+		t.Errorf("got: %v want: %v", err, ErrCurrencyMismatch)
+	}
+}
+
+func TestPriceCmp(t *testing.T) {
+	missing := Price{Currency: CurrencyUSD}
+	tests := []struct {
+		name string
+		p    Price
+		q    Price
+		out  int
+	}{
+		{"less", NewPrice(CurrencyUSD, 1), NewPrice(CurrencyUSD, 2), -1},
+		{"equal", NewPrice(CurrencyUSD, 2), NewPrice(CurrencyUSD, 2), 0},
+		{"greater", NewPrice(CurrencyUSD, 3), NewPrice(CurrencyUSD, 2), 1},
+		{"missing last", missing, NewPrice(CurrencyUSD, 2), 1},
+		{"known first", NewPrice(CurrencyUSD, 2), missing, -1},
+		{"both missing", missing, missing, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.p.Cmp(test.q); got != test.out {
+				t.Errorf("got: %d want: %d", got, test.out)
+			}
+		})
+	}
+}
+
+func TestPricesPrice(t *testing.T) {
+	prices := duskDawn.Prices
+	tests := []struct {
+		currency Currency
+		finish   Finish
+		out      Price
+	}{
+		{CurrencyUSD, FinishNonFoil, NewPrice(CurrencyUSD, 35)},
+		{CurrencyUSD, FinishFoil, NewPrice(CurrencyUSD, 417)},
+		{CurrencyUSD, FinishEtched, Price{Currency: CurrencyUSD}},
+		{CurrencyEUR, FinishNonFoil, NewPrice(CurrencyEUR, 54)},
+		{CurrencyEUR, FinishFoil, NewPrice(CurrencyEUR, 155)},
+		{CurrencyEUR, FinishEtched, Price{Currency: CurrencyEUR}},
+		{CurrencyTix, FinishNonFoil, NewPrice(CurrencyTix, 2)},
+		{CurrencyTix, FinishFoil, Price{Currency: CurrencyTix}},
+		{CurrencyTix, FinishEtched, Price{Currency: CurrencyTix}},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.currency)+" "+string(test.finish), func(t *testing.T) {
+			got, err := prices.Price(test.currency, test.finish)
+			if err != nil {
+				t.Fatalf("Unexpected error getting price: %v", err)
+			}
+			if got != test.out {
+				t.Errorf("got: %#v want: %#v", got, test.out)
+			}
+		})
+	}
+
+	_, err := prices.Price(Currency("gbp"), FinishNonFoil)
+	if err == nil {
+		t.Errorf("expected error for unknown currency")
+	}
+}
+
+func TestPriceTotals(t *testing.T) {
+	totals := PriceTotals{}
+	err := totals.AddPrices(duskDawn.Prices, FinishNonFoil, 4)
+	if err != nil {
+		t.Fatalf("Unexpected error adding prices: %v", err)
+	}
+	err = totals.AddPrices(duskDawn.Prices, FinishEtched, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error adding prices: %v", err)
+	}
+
+	want := PriceTotals{
+		CurrencyUSD: NewPrice(CurrencyUSD, 140),
+		CurrencyEUR: NewPrice(CurrencyEUR, 216),
+		CurrencyTix: NewPrice(CurrencyTix, 8),
+	}
+	if !reflect.DeepEqual(totals, want) {
+		t.Errorf("got: %#v want: %#v", totals, want)
+	}
+}
+
+func TestSumPrices(t *testing.T) {
+	got, err := SumPrices(CurrencyUSD, NewPrice(CurrencyUSD, 35), Price{Currency: CurrencyUSD}, NewPrice(CurrencyUSD, 417))
+	if err != nil {
+		t.Fatalf("Unexpected error summing prices: %v", err)
+	}
+	if want := NewPrice(CurrencyUSD, 452); got != want {
+		t.Errorf("got: %#v want: %#v", got, want)
+	}
+}
@@ -0,0 +1,191 @@
+package scryfall
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// PrintingsSource retrieves every printing of a card. Both Client and
+// CardStore are PrintingsSources, so printing lookups can be served by either
+// the Scryfall API or a local bulk data file.
+type PrintingsSource interface {
+	// PrintingsByOracleID returns every printing of the card with the
+	// given oracle ID.
+	PrintingsByOracleID(ctx context.Context, oracleID string) ([]Card, error)
+}
+
+// PrintingsByOracleID returns every printing of the card with the given oracle
+// ID, in every language. It performs the same search as a card's
+// PrintsSearchURI, and follows every page of the results.
+func (c *Client) PrintingsByOracleID(ctx context.Context, oracleID string) ([]Card, error) {
+	opts := SearchCardsOptions{
+		Unique:              UniqueModePrints,
+		IncludeExtras:       true,
+		IncludeMultilingual: true,
+		IncludeVariations:   true,
+	}
+	return c.searchAllCards(ctx, fmt.Sprintf("oracleid:%s", oracleID), opts)
+}
+
+// CheapestPrintingsOptions holds the options used to find the cheapest
+// printings of a card.
+type CheapestPrintingsOptions struct {
+	// Currency is the currency used to rank printings. The default
+	// currency is CurrencyUSD.
+	Currency Currency
+
+	// Finishes limits the results to the given finishes. All finishes are
+	// included by default.
+	Finishes []Finish
+
+	// Lang limits the results to printings in the given language. All
+	// languages are included by default.
+	Lang Lang
+
+	// Digital limits the results to digital printings if true, or paper
+	// printings if false. Both are included by default.
+	Digital *bool
+
+	// BorderColors limits the results to printings with the given border
+	// colors. All border colors are included by default.
+	BorderColors []BorderColor
+}
+
+func (o CheapestPrintingsOptions) matches(card Card) bool {
+	if len(o.Lang) != 0 && card.Lang != o.Lang {
+		return false
+	}
+	if o.Digital != nil && card.Digital != *o.Digital {
+		return false
+	}
+	if len(o.BorderColors) != 0 {
+		found := false
+		for _, borderColor := range o.BorderColors {
+			if card.BorderColor == borderColor {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (o CheapestPrintingsOptions) matchesFinish(finish Finish) bool {
+	if len(o.Finishes) == 0 {
+		return true
+	}
+
+	for _, f := range o.Finishes {
+		if f == finish {
+			return true
+		}
+	}
+	return false
+}
+
+// PrintingPrice is the price of a particular finish of a printing.
+type PrintingPrice struct {
+	// Card is the printing.
+	Card Card
+
+	// Finish is the finish the price applies to.
+	Finish Finish
+
+	// Price is the price of the printing in the requested currency.
+	Price Price
+}
+
+// CheapestPrintings returns the printings of the card with the given oracle ID
+// ordered from cheapest to most expensive. Each finish of a printing is ranked
+// separately, and finishes without a known price are omitted.
+func CheapestPrintings(ctx context.Context, source PrintingsSource, oracleID string, opts CheapestPrintingsOptions) ([]PrintingPrice, error) {
+	printings, err := source.PrintingsByOracleID(ctx, oracleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return rankPrintings(printings, opts)
+}
+
+// CardPrintingsSource is a PrintingsSource which can also retrieve the
+// printings of a card from the card itself. Client is a CardPrintingsSource.
+type CardPrintingsSource interface {
+	PrintingsSource
+
+	// GetPrintings returns every printing of the card.
+	GetPrintings(ctx context.Context, card Card) ([]Card, error)
+}
+
+// CheapestPrintingsOf is like CheapestPrintings, but finds the printings of the
+// given card. If source is a CardPrintingsSource, such as Client, the card's
+// PrintsSearchURI is followed, otherwise the printings are looked up by the
+// card's oracle IDs. Reversible cards are looked up by the oracle IDs of their
+// faces.
+func CheapestPrintingsOf(ctx context.Context, source PrintingsSource, card Card, opts CheapestPrintingsOptions) ([]PrintingPrice, error) {
+	cardSource, ok := source.(CardPrintingsSource)
+	if !ok {
+		printings := []Card{}
+		seen := map[string]bool{}
+		for _, oracleID := range cardOracleIDs(card) {
+			oraclePrintings, err := source.PrintingsByOracleID(ctx, oracleID)
+			if err != nil {
+				return nil, err
+			}
+			for _, printing := range oraclePrintings {
+				if !seen[printing.ID] {
+					seen[printing.ID] = true
+					printings = append(printings, printing)
+				}
+			}
+		}
+
+		return rankPrintings(printings, opts)
+	}
+
+	printings, err := cardSource.GetPrintings(ctx, card)
+	if err != nil {
+		return nil, err
+	}
+
+	return rankPrintings(printings, opts)
+}
+
+func rankPrintings(printings []Card, opts CheapestPrintingsOptions) ([]PrintingPrice, error) {
+	currency := opts.Currency
+	if len(currency) == 0 {
+		currency = CurrencyUSD
+	}
+
+	prices := []PrintingPrice{}
+	for _, card := range printings {
+		if !opts.matches(card) {
+			continue
+		}
+
+		for _, finish := range card.Finishes {
+			if !opts.matchesFinish(finish) {
+				continue
+			}
+
+			price, err := card.Prices.Price(currency, finish)
+			if err != nil {
+				return nil, err
+			}
+			if !price.Valid {
+				continue
+			}
+
+			prices = append(prices, PrintingPrice{Card: card, Finish: finish, Price: price})
+		}
+	}
+
+	sort.SliceStable(prices, func(i, j int) bool {
+		return prices[i].Price.Cmp(prices[j].Price) < 0
+	})
+	return prices, nil
+}
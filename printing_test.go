@@ -0,0 +1,220 @@
+package scryfall
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+var testPrintings = []Card{
+	{
+		ID:          "lea",
+		OracleID:    "bolt",
+		Lang:        LangEnglish,
+		BorderColor: "black",
+		Finishes:    []Finish{FinishNonFoil},
+		Prices:      Prices{USD: "450.00", EUR: "300.00"},
+	},
+	{
+		ID:          "m10",
+		OracleID:    "bolt",
+		Lang:        LangEnglish,
+		BorderColor: "black",
+		Finishes:    []Finish{FinishNonFoil, FinishFoil},
+		Prices:      Prices{USD: "1.50", USDFoil: "12.00", EUR: "1.10", EURFoil: "9.00"},
+	},
+	{
+		ID:          "m10-de",
+		OracleID:    "bolt",
+		Lang:        LangGerman,
+		BorderColor: "black",
+		Finishes:    []Finish{FinishNonFoil},
+		Prices:      Prices{EUR: "0.80"},
+	},
+	{
+		ID:          "3ed",
+		OracleID:    "bolt",
+		Lang:        LangEnglish,
+		BorderColor: "white",
+		Finishes:    []Finish{FinishNonFoil},
+		Prices:      Prices{USD: "1.00"},
+	},
+	{
+		ID:          "me4",
+		OracleID:    "bolt",
+		Lang:        LangEnglish,
+		BorderColor: "black",
+		Digital:     true,
+		Finishes:    []Finish{FinishNonFoil, FinishFoil},
+		Prices:      Prices{Tix: "0.03"},
+	},
+}
+
+func printingIDs(prices []PrintingPrice) []string {
+	ids := []string{}
+	for _, price := range prices {
+		ids = append(ids, price.Card.ID+"/"+string(price.Finish))
+	}
+	return ids
+}
+
+func TestCheapestPrintings(t *testing.T) {
+	store := NewCardStore(testPrintings)
+	tests := []struct {
+		name string
+		opts CheapestPrintingsOptions
+		out  []string
+	}{
+		{
+			name: "default",
+			opts: CheapestPrintingsOptions{},
+			out:  []string{"3ed/nonfoil", "m10/nonfoil", "m10/foil", "lea/nonfoil"},
+		},
+		{
+			name: "nonfoil black border",
+			opts: CheapestPrintingsOptions{
+				Finishes:     []Finish{FinishNonFoil},
+				BorderColors: []BorderColor{BorderColorBlack},
+			},
+			out: []string{"m10/nonfoil", "lea/nonfoil"},
+		},
+		{
+			name: "eur",
+			opts: CheapestPrintingsOptions{Currency: CurrencyEUR},
+			out:  []string{"m10-de/nonfoil", "m10/nonfoil", "m10/foil", "lea/nonfoil"},
+		},
+		{
+			name: "eur english",
+			opts: CheapestPrintingsOptions{Currency: CurrencyEUR, Lang: LangEnglish},
+			out:  []string{"m10/nonfoil", "m10/foil", "lea/nonfoil"},
+		},
+		{
+			name: "tix digital",
+			opts: CheapestPrintingsOptions{Currency: CurrencyTix, Digital: boolPointer(true)},
+			out:  []string{"me4/nonfoil"},
+		},
+		{
+			name: "paper",
+			opts: CheapestPrintingsOptions{Currency: CurrencyTix, Digital: boolPointer(false)},
+			out:  []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			prices, err := CheapestPrintings(ctx, store, "bolt", test.opts)
+			if err != nil {
+				t.Fatalf("Error finding cheapest printings: %v", err)
+			}
+
+			got := printingIDs(prices)
+			if !reflect.DeepEqual(got, test.out) {
+				t.Errorf("got: %v want: %v", got, test.out)
+			}
+		})
+	}
+}
+
+func TestClientPrintingsByOracleID(t *testing.T) {
+	var serverURL string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("q") != "oracleid:bolt" || query.Get("unique") != "prints" || query.Get("include_multilingual") != "true" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		page := CardListResponse{}
+		if query.Get("page") == "2" {
+			page.Cards = testPrintings[2:]
+		} else {
+			page.Cards = testPrintings[:2]
+			page.HasMore = true
+			page.NextPage = stringPointer(serverURL + "/cards/search?" + query.Encode() + "&page=2")
+		}
+		json.NewEncoder(w).Encode(page)
+	})
+	client, ts, err := setupTestServer("/cards/search", handler)
+	if err != nil {
+		t.Fatalf("Error setting up test server: %v", err)
+	}
+	defer ts.Close()
+	serverURL = ts.URL
+
+	ctx := context.Background()
+	prices, err := CheapestPrintings(ctx, client, "bolt", CheapestPrintingsOptions{})
+	if err != nil {
+		t.Fatalf("Error finding cheapest printings: %v", err)
+	}
+
+	got := printingIDs(prices)
+	want := []string{"3ed/nonfoil", "m10/nonfoil", "m10/foil", "lea/nonfoil"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got: %v want: %v", got, want)
+	}
+}
+
+func TestCheapestPrintingsOf(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("q") != "oracleid:bolt" || query.Get("unique") != "prints" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(CardListResponse{Cards: testPrintings[:2]})
+	})
+	client, ts, err := setupTestServer("/cards/search", handler)
+	if err != nil {
+		t.Fatalf("Error setting up test server: %v", err)
+	}
+	defer ts.Close()
+
+	card := Card{
+		OracleID:        "bolt",
+		PrintsSearchURI: ts.URL + "/cards/search?order=released&q=oracleid%3Abolt&unique=prints",
+	}
+	boltOracleID := "bolt"
+	reversible := Card{
+		ID:        "sld",
+		Lang:      LangEnglish,
+		Layout:    LayoutReversible,
+		Finishes:  []Finish{FinishFoil},
+		Prices:    Prices{USDFoil: "5.00"},
+		CardFaces: []CardFace{{OracleID: &boltOracleID}, {OracleID: &boltOracleID}},
+	}
+	store := NewCardStore(append([]Card{reversible}, testPrintings...))
+	tests := []struct {
+		name   string
+		source PrintingsSource
+		card   Card
+		out    []string
+		err    error
+	}{
+		{"client", client, card, []string{"m10/nonfoil", "m10/foil", "lea/nonfoil"}, nil},
+		{"store", NewCardStore(testPrintings), card, []string{"3ed/nonfoil", "m10/nonfoil", "m10/foil", "lea/nonfoil"}, nil},
+		{"store reversible card", store, reversible, []string{"3ed/nonfoil", "m10/nonfoil", "sld/foil", "m10/foil", "lea/nonfoil"}, nil},
+		{"foreign uri", client, Card{OracleID: "bolt", PrintsSearchURI: "https://example.com/cards/search"}, nil, ErrForeignURI},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			prices, err := CheapestPrintingsOf(ctx, test.source, test.card, CheapestPrintingsOptions{})
+			if !errors.Is(err, test.err) {
+				t.Fatalf("got error: %v want: %v", err, test.err)
+			}
+			if err != nil {
+				return
+			}
+
+			got := printingIDs(prices)
+			if !reflect.DeepEqual(got, test.out) {
+				t.Errorf("got: %v want: %v", got, test.out)
+			}
+		})
+	}
+}
@@ -0,0 +1,239 @@
+package scryfall
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// RawResponse is the JSON body of an API response.
+type RawResponse struct {
+	// URL is the URL of the request which received the response.
+	URL string
+
+	// Body is the JSON body of the response.
+	Body json.RawMessage
+}
+
+type rawResponsesKey struct{}
+
+// WithRawResponses returns a copy of ctx which makes the calls it is passed to
+// append the body of every successful JSON response they receive to raws,
+// alongside the typed results they return. Calls which request several pages
+// append each page. Error responses aren't recorded.
+//
+// Only the calls given the returned context keep the response bodies, so each
+// call can use its own destination. A destination must not be shared by
+// concurrent calls.
+func WithRawResponses(ctx context.Context, raws *[]RawResponse) context.Context {
+	return context.WithValue(ctx, rawResponsesKey{}, raws)
+}
+
+// rawResponses returns the destination set by WithRawResponses, or nil.
+func rawResponses(ctx context.Context) *[]RawResponse {
+	raws, _ := ctx.Value(rawResponsesKey{}).(*[]RawResponse)
+	return raws
+}
+
+// UnknownFields holds the JSON fields of a Scryfall object which this package
+// doesn't model yet, keyed by field name.
+//
+// Card, CardFace, RelatedCard, Prices, Legalities, RelatedURIs, PurchaseURIs,
+// Set, Ruling, BulkData, and CardSymbol keep their unknown fields, returned by
+// their UnknownFields method, and write them back when they're encoded, after
+// the fields they model, so round-tripping an object doesn't drop data.
+// Finding the unknown fields means decoding every object a second time, so
+// they are only kept by clients created WithUnknownFields and by
+// DecodeWithUnknownFields.
+type UnknownFields map[string]json.RawMessage
+
+// extraFields is embedded in the types which keep their UnknownFields. The
+// fields are held through a pointer so those types stay comparable.
+type extraFields struct {
+	unknown *UnknownFields
+}
+
+// UnknownFields returns the fields of the object which this package doesn't
+// model, or nil if there aren't any or they weren't kept, see UnknownFields.
+func (e extraFields) UnknownFields() UnknownFields {
+	if e.unknown == nil {
+		return nil
+	}
+	return *e.unknown
+}
+
+func (e *extraFields) setUnknownFields(unknown UnknownFields) {
+	e.unknown = &unknown
+}
+
+// unknownFieldsSetter is implemented by pointers to the types which embed
+// extraFields.
+type unknownFieldsSetter interface {
+	setUnknownFields(UnknownFields)
+}
+
+// WithUnknownFields returns an option which makes the client keep the
+// UnknownFields of the objects it decodes.
+func WithUnknownFields() ClientOption {
+	return func(o *clientOptions) {
+		o.keepUnknownFields = true
+	}
+}
+
+// DecodeWithUnknownFields decodes the JSON encoded data into v like
+// json.Unmarshal, and keeps the UnknownFields of the objects it decodes. Use
+// it to read objects back from storage without dropping fields this package
+// doesn't model.
+func DecodeWithUnknownFields(data []byte, v interface{}) error {
+	err := json.Unmarshal(data, v)
+	if err != nil {
+		return err
+	}
+
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return nil
+	}
+	collectUnknownFields(data, value.Elem())
+	return nil
+}
+
+// collectUnknownFields walks the JSON value b against v, the addressable value
+// it was decoded into, and stores the fields which v doesn't model in the
+// objects which keep their UnknownFields.
+func collectUnknownFields(b []byte, v reflect.Value) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if isOpaque(v.Type()) {
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		var object map[string]json.RawMessage
+		if json.Unmarshal(b, &object) != nil {
+			return
+		}
+		fields := jsonFields(v.Type())
+		var unknown UnknownFields
+		for key, value := range object {
+			if key == "object" {
+				continue
+			}
+			field, ok := fields[key]
+			if !ok {
+				field, ok = fields[strings.ToLower(key)]
+			}
+			if !ok {
+				if unknown == nil {
+					unknown = UnknownFields{}
+				}
+				unknown[key] = value
+				continue
+			}
+			collectUnknownFields(value, v.FieldByIndex(field.Index))
+		}
+		if setter, ok := v.Addr().Interface().(unknownFieldsSetter); ok && unknown != nil {
+			setter.setUnknownFields(unknown)
+		}
+	case reflect.Slice, reflect.Array:
+		var elems []json.RawMessage
+		if json.Unmarshal(b, &elems) != nil {
+			return
+		}
+		for i := 0; i < len(elems) && i < v.Len(); i++ {
+			collectUnknownFields(elems[i], v.Index(i))
+		}
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return
+		}
+		var object map[string]json.RawMessage
+		if json.Unmarshal(b, &object) != nil {
+			return
+		}
+		for key, value := range object {
+			mapKey := reflect.ValueOf(key).Convert(v.Type().Key())
+			elem := v.MapIndex(mapKey)
+			if !elem.IsValid() {
+				continue
+			}
+			// Map elements aren't addressable, so update a copy.
+			elemCopy := reflect.New(elem.Type()).Elem()
+			elemCopy.Set(elem)
+			collectUnknownFields(value, elemCopy)
+			v.SetMapIndex(mapKey, elemCopy)
+		}
+	}
+}
+
+// encodeWithUnknownFields encodes v, a pointer to a struct, as a JSON object
+// starting with the object field, unless object is empty, and ending with the
+// unknown fields in sorted order. Unknown fields which clash with the
+// struct's fields are skipped.
+func encodeWithUnknownFields(v interface{}, object string, unknown UnknownFields) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if len(object) == 0 && len(unknown) == 0 {
+		return b, nil
+	}
+
+	fields := jsonFields(reflect.TypeOf(v).Elem())
+	keys := []string{}
+	for key := range unknown {
+		if key != "object" && !isKnownField(fields, key) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	if len(object) != 0 {
+		buf.WriteString(`"object":`)
+		objectJSON, _ := json.Marshal(object)
+		buf.Write(objectJSON)
+	}
+	fieldsJSON := bytes.TrimSpace(b[1 : len(b)-1])
+	if len(fieldsJSON) != 0 {
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		buf.Write(fieldsJSON)
+	}
+	for _, key := range keys {
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		keyJSON, _ := json.Marshal(key)
+		buf.Write(keyJSON)
+		buf.WriteByte(':')
+		if len(unknown[key]) == 0 {
+			buf.WriteString("null")
+			continue
+		}
+		buf.Write(unknown[key])
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// isKnownField reports whether the JSON key matches one of the fields returned
+// by jsonFields.
+func isKnownField(fields map[string]reflect.StructField, key string) bool {
+	if _, ok := fields[key]; ok {
+		return true
+	}
+	_, ok := fields[strings.ToLower(key)]
+	return ok
+}
@@ -0,0 +1,229 @@
+package scryfall
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWithRawResponses(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, duskDawnJSON)
+	})
+	client, ts, err := setupTestServer("/cards/937dbc51-b589-4237-9fce-ea5c757f7c48", handler)
+	if err != nil {
+		t.Fatalf("Error setting up test server: %v", err)
+	}
+	defer ts.Close()
+
+	var raws []RawResponse
+	ctx := WithRawResponses(context.Background(), &raws)
+	card, err := client.GetCard(ctx, "937dbc51-b589-4237-9fce-ea5c757f7c48")
+	if err != nil {
+		t.Fatalf("Error getting card: %v", err)
+	}
+	if !reflect.DeepEqual(card, duskDawn) {
+		t.Errorf("got: %#v want: %#v", card, duskDawn)
+	}
+	want := []RawResponse{{URL: ts.URL + "/cards/937dbc51-b589-4237-9fce-ea5c757f7c48", Body: json.RawMessage(duskDawnJSON)}}
+	if !reflect.DeepEqual(raws, want) {
+		t.Errorf("got raw responses: %s want: %s", raws, want)
+	}
+
+	_, err = client.GetCard(context.Background(), "937dbc51-b589-4237-9fce-ea5c757f7c48")
+	if err != nil {
+		t.Fatalf("Error getting card: %v", err)
+	}
+	if len(raws) != 1 {
+		t.Errorf("got %d raw responses, want 1 for the call given the context", len(raws))
+	}
+}
+
+func TestWithUnknownFields(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, duskDawnJSON)
+	})
+	client, ts, err := setupTestServer("/cards/937dbc51-b589-4237-9fce-ea5c757f7c48", handler, WithUnknownFields())
+	if err != nil {
+		t.Fatalf("Error setting up test server: %v", err)
+	}
+	defer ts.Close()
+
+	card, err := client.GetCard(context.Background(), "937dbc51-b589-4237-9fce-ea5c757f7c48")
+	if err != nil {
+		t.Fatalf("Error getting card: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		unknown UnknownFields
+		key     string
+		want    string
+	}{
+		{"card", card.UnknownFields(), "set_type", `"expansion"`},
+		{"card face", card.CardFaces[0].UnknownFields(), "artist_id", `"81995d11-da98-4f8b-89bd-b88ca2ddb06b"`},
+		{"legalities", card.Legalities.UnknownFields(), "explorer", `"legal"`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := string(test.unknown[test.key]); got != test.want {
+				t.Errorf("got %s: %s want: %s", test.key, got, test.want)
+			}
+		})
+	}
+	if card.Prices.UnknownFields() != nil {
+		t.Errorf("got unknown prices fields %v, want none", card.Prices.UnknownFields())
+	}
+
+	sets := []Set{}
+	err = DecodeWithUnknownFields([]byte(`[{"object":"set","code":"aer","foil":false}]`), &sets)
+	if err != nil {
+		t.Fatalf("Error decoding sets: %v", err)
+	}
+	if got := string(sets[0].UnknownFields()["foil"]); got != "false" {
+		t.Errorf("got foil: %s want: false", got)
+	}
+
+	plain := Card{}
+	err = json.Unmarshal([]byte(duskDawnJSON), &plain)
+	if err != nil {
+		t.Fatalf("Error decoding card: %v", err)
+	}
+	if plain.UnknownFields() != nil || plain.Legalities != duskDawn.Legalities {
+		t.Errorf("json.Unmarshal kept unknown fields: %v", plain.UnknownFields())
+	}
+}
+
+func TestUnknownFieldsRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		new  func() interface{}
+	}{
+		{"card", duskDawnJSON, func() interface{} { return &Card{} }},
+		{"set", `{"object":"set","code":"aer","name":"Aether Revolt","foil":false,"nonfoil":true}`, func() interface{} { return &Set{} }},
+		{"card symbol", `{"object":"card_symbol","symbol":"{W}","english":"one white mana","appears_in_mana_costs":true}`, func() interface{} { return &CardSymbol{} }},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			v := test.new()
+			err := DecodeWithUnknownFields([]byte(test.in), v)
+			if err != nil {
+				t.Fatalf("Error decoding: %v", err)
+			}
+			b, err := json.Marshal(v)
+			if err != nil {
+				t.Fatalf("Error encoding: %v", err)
+			}
+			var inFields, outFields map[string]json.RawMessage
+			json.Unmarshal([]byte(test.in), &inFields)
+			json.Unmarshal(b, &outFields)
+			for key := range inFields {
+				if _, ok := outFields[key]; !ok {
+					t.Errorf("field %s was dropped: %s", key, b)
+				}
+			}
+
+			got := test.new()
+			err = DecodeWithUnknownFields(b, got)
+			if err != nil {
+				t.Fatalf("Error decoding: %v", err)
+			}
+			gotJSON, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("Error encoding: %v", err)
+			}
+			if string(gotJSON) != string(b) {
+				t.Errorf("got: %s want: %s", gotJSON, b)
+			}
+		})
+	}
+}
+
+func TestEncodeWithUnknownFields(t *testing.T) {
+	set := Set{Code: "aer"}
+	set.setUnknownFields(UnknownFields{
+		"nonfoil": json.RawMessage("true"),
+		"foil":    json.RawMessage("false"),
+		"code":    json.RawMessage(`"clash"`),
+	})
+	b, err := json.Marshal(set)
+	if err != nil {
+		t.Fatalf("Error encoding set: %v", err)
+	}
+
+	var got Set
+	err = DecodeWithUnknownFields(b, &got)
+	if err != nil {
+		t.Fatalf("Error decoding set: %v", err)
+	}
+	want := UnknownFields{
+		"nonfoil": json.RawMessage("true"),
+		"foil":    json.RawMessage("false"),
+	}
+	if got.Code != "aer" || !reflect.DeepEqual(got.UnknownFields(), want) {
+		t.Errorf("got: %#v want unknown fields: %#v", got, want)
+	}
+	if prefix := `{"object":"set",`; string(b[:len(prefix)]) != prefix {
+		t.Errorf("got: %s want prefix: %s", b, prefix)
+	}
+}
+
+func TestRoundTripCorpus(t *testing.T) {
+	types := map[string]func() interface{}{
+		"card":        func() interface{} { return &Card{} },
+		"set":         func() interface{} { return &Set{} },
+		"ruling":      func() interface{} { return &Ruling{} },
+		"bulk_data":   func() interface{} { return &BulkData{} },
+		"oauth_grant": func() interface{} { return &OAuthGrant{} },
+	}
+
+	paths, err := filepath.Glob(filepath.Join("testdata", "roundtrip", "*.json"))
+	if err != nil {
+		t.Fatalf("Error listing fixtures: %v", err)
+	}
+	if len(paths) == 0 {
+		t.Fatal("no round trip fixtures found")
+	}
+
+	for _, path := range paths {
+		name := strings.TrimSuffix(filepath.Base(path), ".json")
+		t.Run(name, func(t *testing.T) {
+			in, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("Error reading fixture: %v", err)
+			}
+			newValue, ok := types[name]
+			if i := strings.LastIndex(name, "_"); !ok && i > 0 {
+				newValue, ok = types[name[:i]]
+			}
+			if !ok {
+				t.Fatalf("no type matches the fixture %s", filepath.Base(path))
+			}
+
+			v := newValue()
+			err = DecodeWithUnknownFields(in, v)
+			if err != nil {
+				t.Fatalf("Error decoding: %v", err)
+			}
+			out, err := json.Marshal(v)
+			if err != nil {
+				t.Fatalf("Error encoding: %v", err)
+			}
+
+			var want, got interface{}
+			json.Unmarshal(in, &want)
+			json.Unmarshal(out, &got)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("got: %s want: %s", out, in)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package scryfall
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotMeld is returned when requesting the meld trio of a card which isn't
+// part of a meld.
+var ErrNotMeld = errors.New("card is not part of a meld")
+
+// ErrIncompleteMeld is returned when requesting the meld trio of a card whose
+// meld parts or result can't all be found.
+var ErrIncompleteMeld = errors.New("meld is incomplete")
+
+// RelatedCards groups the full card objects of a card's related parts by the
+// role they play in the relationship.
+type RelatedCards map[Component][]Card
+
+// ResolveRelatedCards fetches the full card object of every part in the card's
+// AllParts with as few cards/collection requests as possible, and groups them
+// by Component. Related cards are returned in the order they appear in
+// AllParts. Parts which Scryfall can no longer find are omitted.
+func (c *Client) ResolveRelatedCards(ctx context.Context, card Card) (RelatedCards, error) {
+	related := RelatedCards{}
+	if len(card.AllParts) == 0 {
+		return related, nil
+	}
+
+	identifiers := make([]CardIdentifier, 0, len(card.AllParts))
+	for _, part := range card.AllParts {
+		identifiers = append(identifiers, CardIdentifier{ID: part.ID})
+	}
+	response, err := c.getAllCardsByIdentifiers(ctx, identifiers)
+	if err != nil {
+		return nil, err
+	}
+
+	cardsByID := make(map[string]Card, len(response.Data))
+	for _, relatedCard := range response.Data {
+		cardsByID[relatedCard.ID] = relatedCard
+	}
+	for _, part := range card.AllParts {
+		relatedCard, ok := cardsByID[part.ID]
+		if !ok {
+			continue
+		}
+		related[part.Component] = append(related[part.Component], relatedCard)
+	}
+
+	return related, nil
+}
+
+// TokensCreatedBy returns the tokens and emblems that the card can create.
+func (c *Client) TokensCreatedBy(ctx context.Context, card Card) ([]Card, error) {
+	related, err := c.ResolveRelatedCards(ctx, card)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := []Card{}
+	for _, token := range related[ComponentToken] {
+		if token.ID != card.ID {
+			tokens = append(tokens, token)
+		}
+	}
+
+	return tokens, nil
+}
+
+// Meld is the complete set of cards involved in a meld: the two cards that
+// meld together and the card they meld into.
+type Meld struct {
+	// Parts is the two cards that meld together.
+	Parts []Card
+
+	// Result is the card the parts meld into.
+	Result Card
+}
+
+// MeldTrio returns the complete meld the card is involved in. The card can be
+// either one of the meld parts or the meld result. ErrNotMeld is returned if
+// the card isn't part of a meld, and ErrIncompleteMeld if Scryfall can't find
+// every card of the meld.
+func (c *Client) MeldTrio(ctx context.Context, card Card) (Meld, error) {
+	isMeld := false
+	for _, part := range card.AllParts {
+		if part.Component == ComponentMeldPart || part.Component == ComponentMeldResult {
+			isMeld = true
+			break
+		}
+	}
+	if !isMeld {
+		return Meld{}, ErrNotMeld
+	}
+
+	related, err := c.ResolveRelatedCards(ctx, card)
+	if err != nil {
+		return Meld{}, err
+	}
+
+	parts := related[ComponentMeldPart]
+	results := related[ComponentMeldResult]
+	if len(parts) != 2 || len(results) != 1 {
+		return Meld{}, ErrIncompleteMeld
+	}
+
+	return Meld{Parts: parts, Result: results[0]}, nil
+}
@@ -0,0 +1,197 @@
+package scryfall
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// collectionHandler serves cards/collection requests, reporting the
+// identifiers which don't identify any of cards as not found.
+func collectionHandler(t *testing.T, cards []Card, requests *int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+
+		request := GetCardsByIdentifiersRequest{}
+		err := json.NewDecoder(r.Body).Decode(&request)
+		if err != nil {
+			t.Fatalf("Error decoding request: %v", err)
+		}
+		if len(request.Identifiers) > maxCardIdentifiers {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			fmt.Fprintln(w, `{"object": "error", "code": "too_many_identifiers", "status": 422, "details": "too many identifiers"}`)
+			return
+		}
+
+		response := GetCardsByIdentifiersResponse{NotFound: []CardIdentifier{}, Data: []Card{}}
+		for _, identifier := range request.Identifiers {
+			found := false
+			for _, card := range cards {
+				if testIdentifies(identifier, card) {
+					response.Data = append(response.Data, card)
+					found = true
+					break
+				}
+			}
+			if !found {
+				response.NotFound = append(response.NotFound, identifier)
+			}
+		}
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// testIdentifies reports whether the identifier identifies the card, like
+// Scryfall does when looking up a collection.
+func testIdentifies(identifier CardIdentifier, card Card) bool {
+	switch {
+	case len(identifier.ID) != 0:
+		return identifier.ID == card.ID
+	case len(identifier.CollectorNumber) != 0:
+		return identifier.Set == card.Set && identifier.CollectorNumber == card.CollectorNumber
+	case len(identifier.Name) != 0:
+		return strings.EqualFold(identifier.Name, card.Name) && (len(identifier.Set) == 0 || identifier.Set == card.Set)
+	}
+	return false
+}
+
+func cardIDs(cards []Card) []string {
+	ids := []string{}
+	for _, card := range cards {
+		ids = append(ids, card.ID)
+	}
+	return ids
+}
+
+var (
+	bruna = Card{
+		ID:     "bruna",
+		Name:   "Bruna, the Fading Light",
+		Layout: LayoutMeld,
+		AllParts: []RelatedCard{
+			{ID: "bruna", Component: ComponentMeldPart},
+			{ID: "gisela", Component: ComponentMeldPart},
+			{ID: "brisela", Component: ComponentMeldResult},
+		},
+	}
+	gisela  = Card{ID: "gisela", Name: "Gisela, the Broken Blade", Layout: LayoutMeld}
+	brisela = Card{ID: "brisela", Name: "Brisela, Voice of Nightmares", Layout: LayoutMeld}
+
+	raiseTheAlarm = Card{
+		ID:   "raise-the-alarm",
+		Name: "Raise the Alarm",
+		AllParts: []RelatedCard{
+			{ID: "raise-the-alarm", Component: ComponentComboPiece},
+			{ID: "soldier", Component: ComponentToken},
+			{ID: "deleted", Component: ComponentToken},
+		},
+	}
+	soldier = Card{ID: "soldier", Name: "Soldier", Layout: LayoutToken}
+)
+
+func TestMeldTrio(t *testing.T) {
+	requests := 0
+	handler := collectionHandler(t, []Card{bruna, gisela, brisela}, &requests)
+	client, ts, err := setupTestServer("/cards/collection", handler)
+	if err != nil {
+		t.Fatalf("Error setting up test server: %v", err)
+	}
+	defer ts.Close()
+
+	ctx := context.Background()
+	meld, err := client.MeldTrio(ctx, bruna)
+	if err != nil {
+		t.Fatalf("Error getting meld trio: %v", err)
+	}
+
+	got := append(cardIDs(meld.Parts), meld.Result.ID)
+	want := []string{"bruna", "gisela", "brisela"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got: %v want: %v", got, want)
+	}
+	if requests != 1 {
+		t.Errorf("got %d requests, want 1", requests)
+	}
+
+	_, err = client.MeldTrio(ctx, raiseTheAlarm)
+	if err != ErrNotMeld {
+		t.Errorf("got: %v want: %v", err, ErrNotMeld)
+	}
+	if requests != 1 {
+		t.Errorf("got %d requests, want 1", requests)
+	}
+}
+
+func TestMeldTrioIncomplete(t *testing.T) {
+	requests := 0
+	handler := collectionHandler(t, []Card{bruna, gisela}, &requests)
+	client, ts, err := setupTestServer("/cards/collection", handler)
+	if err != nil {
+		t.Fatalf("Error setting up test server: %v", err)
+	}
+	defer ts.Close()
+
+	_, err = client.MeldTrio(context.Background(), bruna)
+	if err != ErrIncompleteMeld {
+		t.Errorf("got: %v want: %v", err, ErrIncompleteMeld)
+	}
+}
+
+func TestTokensCreatedBy(t *testing.T) {
+	requests := 0
+	handler := collectionHandler(t, []Card{raiseTheAlarm, soldier}, &requests)
+	client, ts, err := setupTestServer("/cards/collection", handler)
+	if err != nil {
+		t.Fatalf("Error setting up test server: %v", err)
+	}
+	defer ts.Close()
+
+	ctx := context.Background()
+	tokens, err := client.TokensCreatedBy(ctx, raiseTheAlarm)
+	if err != nil {
+		t.Fatalf("Error getting tokens: %v", err)
+	}
+
+	got := cardIDs(tokens)
+	want := []string{"soldier"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got: %v want: %v", got, want)
+	}
+}
+
+func TestResolveRelatedCardsBatches(t *testing.T) {
+	cards := []Card{}
+	card := Card{ID: "source"}
+	for i := 0; i < 2*maxCardIdentifiers+1; i++ {
+		id := fmt.Sprintf("piece-%d", i)
+		cards = append(cards, Card{ID: id})
+		card.AllParts = append(card.AllParts, RelatedCard{ID: id, Component: ComponentComboPiece})
+	}
+
+	requests := 0
+	handler := collectionHandler(t, cards, &requests)
+	client, ts, err := setupTestServer("/cards/collection", handler)
+	if err != nil {
+		t.Fatalf("Error setting up test server: %v", err)
+	}
+	defer ts.Close()
+
+	ctx := context.Background()
+	related, err := client.ResolveRelatedCards(ctx, card)
+	if err != nil {
+		t.Fatalf("Error resolving related cards: %v", err)
+	}
+
+	got := cardIDs(related[ComponentComboPiece])
+	want := cardIDs(cards)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got: %v want: %v", got, want)
+	}
+	if requests != 3 {
+		t.Errorf("got %d requests, want 3", requests)
+	}
+}
@@ -2,7 +2,10 @@ package scryfall
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/url"
 )
 
 // Source indicates which company produced the ruling.
@@ -26,6 +29,9 @@ const (
 // to provide additional context for the card, or explain how the card works in an
 // unofficial format (such as Duel Commander).
 type Ruling struct {
+	// OracleID is the oracle ID of the card the ruling applies to.
+	OracleID string `json:"oracle_id"`
+
 	// Source indicates which company produced the ruling.
 	Source Source `json:"source"`
 
@@ -34,6 +40,15 @@ type Ruling struct {
 
 	// Comment is the text of the ruling.
 	Comment string `json:"comment"`
+
+	extraFields
+}
+
+// MarshalJSON encodes the ruling in Scryfall's format, including its
+// UnknownFields.
+func (r Ruling) MarshalJSON() ([]byte, error) {
+	type ruling Ruling
+	return encodeWithUnknownFields((*ruling)(&r), "ruling", r.UnknownFields())
 }
 
 func (c *Client) getRulings(ctx context.Context, url string) ([]Ruling, error) {
@@ -71,6 +86,9 @@ func (c *Client) GetRulingsByArenaID(ctx context.Context, arenaID int) ([]Ruling
 
 // GetRulingsBySetCodeAndCollectorNumber returns a list of rulings for the card
 // with the given set code and collector number.
+//
+// Deprecated: Collector numbers aren't always numeric. Use
+// GetRulingsBySetCodeAndCollectorNumberString instead.
 func (c *Client) GetRulingsBySetCodeAndCollectorNumber(ctx context.Context, setCode string, collectorNumber int) ([]Ruling, error) {
 	rulingsURL := fmt.Sprintf("cards/%s/%d/rulings", setCode, collectorNumber)
 	return c.getRulings(ctx, rulingsURL)
@@ -81,3 +99,143 @@ func (c *Client) GetRulings(ctx context.Context, id string) ([]Ruling, error) {
 	rulingsURL := fmt.Sprintf("cards/%s/rulings", id)
 	return c.getRulings(ctx, rulingsURL)
 }
+
+// GetRulingsBySetCodeAndCollectorNumberString returns a list of rulings for
+// the card with the given set code and collector number, such as "12a" or
+// "★".
+func (c *Client) GetRulingsBySetCodeAndCollectorNumberString(ctx context.Context, setCode string, collectorNumber string) ([]Ruling, error) {
+	rulingsURL := fmt.Sprintf("cards/%s/%s/rulings", setCode, url.PathEscape(collectorNumber))
+	return c.getRulings(ctx, rulingsURL)
+}
+
+// GetRulingsByOracleID returns the rulings of the given cards keyed by oracle
+// ID. Every printing of a card shares the same rulings, so the rulings are
+// only requested once per oracle ID no matter how many printings are given.
+func (c *Client) GetRulingsByOracleID(ctx context.Context, cards []Card) (map[string][]Ruling, error) {
+	rulingsByOracleID := map[string][]Ruling{}
+	for _, card := range cards {
+		oracleIDs := cardOracleIDs(card)
+		if len(oracleIDs) == 0 {
+			continue
+		}
+		fetched := true
+		for _, oracleID := range oracleIDs {
+			if _, ok := rulingsByOracleID[oracleID]; !ok {
+				fetched = false
+			}
+		}
+		if fetched {
+			continue
+		}
+
+		var rulings []Ruling
+		var err error
+		if len(card.RulingsURI) != 0 {
+			rulings, err = c.GetRulingsFor(ctx, card)
+		} else {
+			rulings, err = c.GetRulings(ctx, card.ID)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, oracleID := range oracleIDs {
+			rulingsByOracleID[oracleID] = []Ruling{}
+		}
+		for _, ruling := range rulings {
+			oracleID := ruling.OracleID
+			if len(oracleID) == 0 {
+				oracleID = oracleIDs[0]
+			}
+			rulingsByOracleID[oracleID] = appendRuling(rulingsByOracleID[oracleID], ruling)
+		}
+	}
+
+	return rulingsByOracleID, nil
+}
+
+// appendRuling appends the ruling to rulings unless an identical ruling is
+// already present.
+func appendRuling(rulings []Ruling, ruling Ruling) []Ruling {
+	for _, r := range rulings {
+		if r.Source == ruling.Source && r.PublishedAt.Equal(ruling.PublishedAt.Time) && r.Comment == ruling.Comment {
+			return rulings
+		}
+	}
+
+	return append(rulings, ruling)
+}
+
+// RulingsIndex is an in-memory index of rulings keyed by oracle ID, usually
+// loaded from Scryfall's rulings bulk data file. A RulingsIndex answers
+// lookups locally so they don't cost an API request.
+//
+// A RulingsIndex must not be modified after it is created, but it is safe for
+// concurrent use.
+type RulingsIndex struct {
+	byOracleID map[string][]Ruling
+}
+
+// NewRulingsIndex returns a RulingsIndex containing the given rulings.
+// Rulings without an oracle ID are ignored and duplicate rulings are only
+// indexed once.
+func NewRulingsIndex(rulings []Ruling) *RulingsIndex {
+	idx := &RulingsIndex{
+		byOracleID: map[string][]Ruling{},
+	}
+	for _, ruling := range rulings {
+		if len(ruling.OracleID) == 0 {
+			continue
+		}
+		idx.byOracleID[ruling.OracleID] = appendRuling(idx.byOracleID[ruling.OracleID], ruling)
+	}
+
+	return idx
+}
+
+// LoadRulingsIndex decodes a JSON array of rulings, such as the Scryfall
+// rulings bulk data file, and returns a RulingsIndex containing them. The
+// rulings are decoded one at a time so the raw file is never held in memory.
+func LoadRulingsIndex(r io.Reader) (*RulingsIndex, error) {
+	rulings := []Ruling{}
+	err := decodeJSONArray(r, "rulings", func(decoder *json.Decoder) error {
+		ruling := Ruling{}
+		err := decoder.Decode(&ruling)
+		if err != nil {
+			return err
+		}
+		rulings = append(rulings, ruling)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewRulingsIndex(rulings), nil
+}
+
+// Len returns the number of oracle IDs with rulings in the index.
+func (idx *RulingsIndex) Len() int {
+	return len(idx.byOracleID)
+}
+
+// Rulings returns the rulings of the card with the given oracle ID. The
+// returned slice must not be modified.
+func (idx *RulingsIndex) Rulings(oracleID string) []Ruling {
+	return idx.byOracleID[oracleID]
+}
+
+// RulingsFor returns the rulings of the card. The rulings of every face are
+// returned for reversible cards, whose faces have their own oracle IDs.
+func (idx *RulingsIndex) RulingsFor(card Card) []Ruling {
+	oracleIDs := cardOracleIDs(card)
+	if len(oracleIDs) == 1 {
+		return idx.Rulings(oracleIDs[0])
+	}
+
+	rulings := []Ruling{}
+	for _, oracleID := range oracleIDs {
+		rulings = append(rulings, idx.Rulings(oracleID)...)
+	}
+	return rulings
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -160,3 +161,116 @@ func TestGetRulings(t *testing.T) {
 		t.Errorf("got: %#v want: %#v", rulings, want)
 	}
 }
+
+func TestGetRulingsBySetCodeAndCollectorNumberString(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"object": "list", "has_more": false, "data": [{"object": "ruling", "oracle_id": "59d2a6f4-7b1d-4a6f-9c3e-6c8e9f2b1a3d", "source": "wotc", "published_at": "2004-10-04", "comment": "This card is a split card."}]}`)
+	})
+	client, ts, err := setupTestServer("/cards/ust/49a/rulings", handler)
+	if err != nil {
+		t.Fatalf("Error setting up test server: %v", err)
+	}
+	defer ts.Close()
+
+	ctx := context.Background()
+	rulings, err := client.GetRulingsBySetCodeAndCollectorNumberString(ctx, "ust", "49a")
+	if err != nil {
+		t.Fatalf("Error getting rulings: %v", err)
+	}
+
+	want := []Ruling{
+		{
+			OracleID:    "59d2a6f4-7b1d-4a6f-9c3e-6c8e9f2b1a3d",
+			Source:      SourceWOTC,
+			PublishedAt: Date{Time: time.Date(2004, 10, 04, 0, 0, 0, 0, time.FixedZone("UTC-8", -8*60*60))},
+			Comment:     "This card is a split card.",
+		},
+	}
+	if !reflect.DeepEqual(rulings, want) {
+		t.Errorf("got: %#v want: %#v", rulings, want)
+	}
+}
+
+func TestGetRulingsByOracleID(t *testing.T) {
+	requests := 0
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/cards/a1/rulings":
+			fmt.Fprintln(w, `{"object": "list", "has_more": false, "data": [{"object": "ruling", "oracle_id": "a", "source": "wotc", "published_at": "2020-01-01", "comment": "Ruling A."}]}`)
+		case "/cards/b1/rulings":
+			fmt.Fprintln(w, `{"object": "list", "has_more": false, "data": []}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintln(w, `{"object": "error", "code": "not_found", "status": 404, "details": "Not found."}`)
+		}
+	})
+	client, ts, err := setupTestServer("/cards/", handler)
+	if err != nil {
+		t.Fatalf("Error setting up test server: %v", err)
+	}
+	defer ts.Close()
+
+	cards := []Card{
+		{ID: "a1", OracleID: "a", RulingsURI: ts.URL + "/cards/a1/rulings"},
+		{ID: "a2", OracleID: "a", RulingsURI: ts.URL + "/cards/a2/rulings"},
+		{ID: "b1", OracleID: "b"},
+	}
+	ctx := context.Background()
+	rulings, err := client.GetRulingsByOracleID(ctx, cards)
+	if err != nil {
+		t.Fatalf("Error getting rulings: %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("got %d requests, want 2", requests)
+	}
+
+	want := map[string][]Ruling{
+		"a": {
+			{
+				OracleID:    "a",
+				Source:      SourceWOTC,
+				PublishedAt: Date{Time: time.Date(2020, 1, 1, 0, 0, 0, 0, time.FixedZone("UTC-8", -8*60*60))},
+				Comment:     "Ruling A.",
+			},
+		},
+		"b": {},
+	}
+	if !reflect.DeepEqual(rulings, want) {
+		t.Errorf("got: %#v want: %#v", rulings, want)
+	}
+}
+
+func TestLoadRulingsIndex(t *testing.T) {
+	bulk := `[
+		{"object": "ruling", "oracle_id": "a", "source": "wotc", "published_at": "2020-01-01", "comment": "Ruling A."},
+		{"object": "ruling", "oracle_id": "a", "source": "wotc", "published_at": "2020-01-01", "comment": "Ruling A."},
+		{"object": "ruling", "oracle_id": "b", "source": "scryfall", "published_at": "2021-01-01", "comment": "Ruling B."},
+		{"object": "ruling", "oracle_id": "c", "source": "wotc", "published_at": "2022-01-01", "comment": "Ruling C."}
+	]`
+	idx, err := LoadRulingsIndex(strings.NewReader(bulk))
+	if err != nil {
+		t.Fatalf("Error loading rulings index: %v", err)
+	}
+	if idx.Len() != 3 {
+		t.Errorf("got %d oracle IDs, want 3", idx.Len())
+	}
+
+	tests := []struct {
+		card     Card
+		comments []string
+	}{
+		{Card{OracleID: "a"}, []string{"Ruling A."}},
+		{Card{OracleID: "d"}, []string{}},
+		{Card{CardFaces: []CardFace{{OracleID: stringPointer("b")}, {OracleID: stringPointer("c")}}}, []string{"Ruling B.", "Ruling C."}},
+	}
+	for _, test := range tests {
+		comments := []string{}
+		for _, ruling := range idx.RulingsFor(test.card) {
+			comments = append(comments, ruling.Comment)
+		}
+		if !reflect.DeepEqual(comments, test.comments) {
+			t.Errorf("got: %#v want: %#v", comments, test.comments)
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"reflect"
 	"strings"
 	"time"
 
@@ -25,6 +26,10 @@ const (
 
 	dateFormat      = "2006-01-02"
 	timestampFormat = "2006-01-02T15:04:05.999Z07:00"
+
+	// timestampMarshalFormat is the format Scryfall writes timestamps in,
+	// with milliseconds and a numeric UTC offset.
+	timestampMarshalFormat = "2006-01-02T15:04:05.000-07:00"
 )
 
 // ErrMultipleSecrets is returned if both the grant and client secret are set
@@ -74,7 +79,13 @@ func (d *Date) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
-func (d *Date) MarshalJSON() ([]byte, error) {
+// MarshalJSON encodes the date in Scryfall's format, or as null if it's the
+// zero date.
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+
 	return []byte(fmt.Sprintf("\"%s\"", d.Format(dateFormat))), nil
 }
 
@@ -98,19 +109,35 @@ func (t *Timestamp) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON encodes the timestamp in Scryfall's format, or as null if it's
+// the zero time.
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+
+	return []byte(fmt.Sprintf("\"%s\"", t.Format(timestampMarshalFormat))), nil
+}
+
 // Error is a Scryfall API error response.
 type Error struct {
 	Status   int      `json:"status"`
 	Code     string   `json:"code"`
 	Details  string   `json:"details"`
-	Type     *string  `json:"type"`
-	Warnings []string `json:"warnings"`
+	Type     *string  `json:"type,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
 }
 
 func (e *Error) Error() string {
 	return fmt.Sprintf("%s: %s", e.Code, e.Details)
 }
 
+// MarshalJSON encodes the error in Scryfall's format.
+func (e Error) MarshalJSON() ([]byte, error) {
+	type scryfallError Error
+	return encodeWithUnknownFields((*scryfallError)(&e), "error", nil)
+}
+
 type clientOptions struct {
 	baseURL      string
 	userAgent    string
@@ -118,6 +145,9 @@ type clientOptions struct {
 	grantSecret  string
 	client       *http.Client
 	limiter      ratelimit.Limiter
+
+	onSchemaDrift     func(SchemaDrift)
+	keepUnknownFields bool
 }
 
 // ClientOption configures the Scryfall API client.
@@ -178,6 +208,9 @@ type Client struct {
 
 	client  *http.Client
 	limiter ratelimit.Limiter
+
+	onSchemaDrift     func(SchemaDrift)
+	keepUnknownFields bool
 }
 
 // NewClient returns a new Scryfall API client.
@@ -212,20 +245,24 @@ func NewClient(options ...ClientOption) (*Client, error) {
 	}
 
 	c := &Client{
-		baseURL:       baseURL,
-		userAgent:     co.userAgent,
-		authorization: authorization,
-		client:        co.client,
-		limiter:       co.limiter,
+		baseURL:           baseURL,
+		userAgent:         co.userAgent,
+		authorization:     authorization,
+		client:            co.client,
+		limiter:           co.limiter,
+		onSchemaDrift:     co.onSchemaDrift,
+		keepUnknownFields: co.keepUnknownFields,
 	}
 	return c, nil
 }
 
-func (c *Client) doReq(ctx context.Context, req *http.Request, respBody interface{}) error {
+// do sends the request with the client's User-Agent, once the rate limiter
+// allows it. The Authorization header is only sent to the configured base URL
+// so credentials never leak to other hosts such as Scryfall's image CDN.
+func (c *Client) do(ctx context.Context, req *http.Request) (*http.Response, error) {
 	req.Header.Set("User-Agent", c.userAgent)
-	req.Header.Set("Accept", "application/json")
 
-	if len(c.authorization) != 0 {
+	if len(c.authorization) != 0 && req.URL.Scheme == c.baseURL.Scheme && req.URL.Host == c.baseURL.Host {
 		req.Header.Set("Authorization", c.authorization)
 	}
 	reqWithContext := req.WithContext(ctx)
@@ -234,7 +271,13 @@ func (c *Client) doReq(ctx context.Context, req *http.Request, respBody interfac
 		c.limiter.Take()
 	}
 
-	resp, err := c.client.Do(reqWithContext)
+	return c.client.Do(reqWithContext)
+}
+
+func (c *Client) doReq(ctx context.Context, req *http.Request, respBody interface{}) error {
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.do(ctx, req)
 	if err != nil {
 		return err
 	}
@@ -251,7 +294,38 @@ func (c *Client) doReq(ctx context.Context, req *http.Request, respBody interfac
 		return scryfallErr
 	}
 
-	return decoder.Decode(respBody)
+	raws := rawResponses(ctx)
+	if c.onSchemaDrift == nil && !c.keepUnknownFields && raws == nil {
+		return decoder.Decode(respBody)
+	}
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(b, respBody)
+	if err != nil {
+		return err
+	}
+	if raws != nil {
+		*raws = append(*raws, RawResponse{URL: req.URL.String(), Body: b})
+	}
+
+	// The data of lists is checked by listGet once it has been decoded.
+	list, isList := respBody.(*listResponse)
+	if c.keepUnknownFields && !isList {
+		collectUnknownFields(b, reflect.ValueOf(respBody).Elem())
+	}
+	if c.onSchemaDrift == nil {
+		return nil
+	}
+	if isList {
+		list.url = req.URL.String()
+		list.raw = b
+		return nil
+	}
+	c.reportSchemaDrift(req.URL.String(), b, respBody)
+	return nil
 }
 
 func (c *Client) get(ctx context.Context, relativeURL string, respBody interface{}) error {
@@ -317,6 +391,11 @@ type listResponse struct {
 	// will not contain the all of the information you requested. You should
 	// fix the warnings and re-submit your request.
 	Warnings []string `json:"warnings"`
+
+	// url and raw are the request URL and response body, which are only
+	// kept for strict decoding.
+	url string
+	raw []byte
 }
 
 func (c *Client) listGet(ctx context.Context, url string, v interface{}) error {
@@ -326,5 +405,16 @@ func (c *Client) listGet(ctx context.Context, url string, v interface{}) error {
 		return err
 	}
 
-	return json.Unmarshal(response.Data, v)
+	err = json.Unmarshal(response.Data, v)
+	if err != nil {
+		return err
+	}
+
+	if c.keepUnknownFields {
+		collectUnknownFields(response.Data, reflect.ValueOf(v).Elem())
+	}
+	if c.onSchemaDrift != nil {
+		c.reportListSchemaDrift(response, v)
+	}
+	return nil
 }
@@ -2,6 +2,7 @@ package scryfall
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -81,6 +82,10 @@ func TestDateMarshalJSON(t *testing.T) {
 			Date{Time: time.Date(2018, 4, 27, 0, 0, 0, 0, time.FixedZone("UTC-8", -8*60*60))},
 			[]byte("\"2018-04-27\""),
 		},
+		{
+			Date{},
+			[]byte("null"),
+		},
 	}
 	for _, test := range tests {
 		t.Run(string(test.out), func(t *testing.T) {
@@ -155,6 +160,37 @@ func TestTimestampUnmarshalJSON(t *testing.T) {
 	}
 }
 
+func TestTimestampMarshalJSON(t *testing.T) {
+	tests := []struct {
+		in  Timestamp
+		out []byte
+	}{
+		{
+			Timestamp{Time: time.Date(2018, 12, 31, 9, 5, 7, 949000000, time.UTC)},
+			[]byte("\"2018-12-31T09:05:07.949+00:00\""),
+		},
+		{
+			Timestamp{Time: time.Date(2018, 12, 1, 14, 31, 43, 0, time.FixedZone("UTC-5", -5*60*60))},
+			[]byte("\"2018-12-01T14:31:43.000-05:00\""),
+		},
+		{
+			Timestamp{},
+			[]byte("null"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(string(test.out), func(t *testing.T) {
+			got, err := json.Marshal(test.in)
+			if err != nil {
+				t.Fatalf("Unexpected error while marshaling timestamp: %v", err)
+			}
+			if string(got) != string(test.out) {
+				t.Errorf("got: %s want: %s", got, test.out)
+			}
+		})
+	}
+}
+
 func TestErrorError(t *testing.T) {
 	want := "not_found: The requested object or REST method was not found."
 	err := Error{
@@ -0,0 +1,108 @@
+package scryfalltest
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+)
+
+// fixtureIDs is the number of fixture IDs handed out so far.
+var fixtureIDs uint64
+
+// NewID returns a new unique ID shaped like a Scryfall UUID.
+func NewID() string {
+	n := atomic.AddUint64(&fixtureIDs, 1)
+	return fmt.Sprintf("00000000-0000-4000-8000-%012d", n)
+}
+
+// fixtureDate is the release date of fixtures.
+var fixtureDate = scryfall.Date{Time: time.Date(2020, 1, 1, 0, 0, 0, 0, time.FixedZone("UTC-8", -8*60*60))}
+
+// NewCard returns a card fixture with the given name and plausible defaults:
+// a new ID and oracle ID, English, normal layout, and collector number 1 of the
+// set "tst". The modifiers are applied in order to customize the card.
+//
+// API URIs such as RulingsURI are left empty by the fixture builders since
+// they depend on the URL of the Server.
+func NewCard(name string, modifiers ...func(*scryfall.Card)) scryfall.Card {
+	id := NewID()
+	card := scryfall.Card{
+		ID:              id,
+		OracleID:        NewID(),
+		Name:            name,
+		Lang:            scryfall.LangEnglish,
+		Layout:          scryfall.LayoutNormal,
+		TypeLine:        "Creature",
+		Set:             "tst",
+		SetName:         "Test Set",
+		CollectorNumber: "1",
+		Rarity:          scryfall.RarityCommon,
+		ReleasedAt:      fixtureDate,
+		Finishes:        []scryfall.Finish{scryfall.FinishNonFoil},
+	}
+	for _, modify := range modifiers {
+		modify(&card)
+	}
+
+	return card
+}
+
+// NewSet returns a set fixture with the given code and plausible defaults.
+// The modifiers are applied in order to customize the set.
+func NewSet(code string, modifiers ...func(*scryfall.Set)) scryfall.Set {
+	id := NewID()
+	releasedAt := fixtureDate
+	set := scryfall.Set{
+		ID:          id,
+		Code:        strings.ToLower(code),
+		Name:        "Test Set " + strings.ToUpper(code),
+		ScryfallURI: "https://scryfall.com/sets/" + strings.ToLower(code),
+		SetType:     scryfall.SetTypeExpansion,
+		ReleasedAt:  &releasedAt,
+	}
+	for _, modify := range modifiers {
+		modify(&set)
+	}
+
+	return set
+}
+
+// NewRuling returns a Wizards of the Coast ruling fixture for the card with
+// the given oracle ID. The modifiers are applied in order to customize the
+// ruling.
+func NewRuling(oracleID, comment string, modifiers ...func(*scryfall.Ruling)) scryfall.Ruling {
+	ruling := scryfall.Ruling{
+		OracleID:    oracleID,
+		Source:      scryfall.SourceWOTC,
+		PublishedAt: fixtureDate,
+		Comment:     comment,
+	}
+	for _, modify := range modifiers {
+		modify(&ruling)
+	}
+
+	return ruling
+}
+
+// NewBulkData returns a bulk data fixture of the given type, such as
+// "default_cards" or "rulings". The modifiers are applied in order to
+// customize the bulk data item.
+func NewBulkData(typ string, modifiers ...func(*scryfall.BulkData)) scryfall.BulkData {
+	id := NewID()
+	bulkData := scryfall.BulkData{
+		ID:              id,
+		Type:            typ,
+		UpdatedAt:       scryfall.Timestamp{Time: fixtureDate.Time.UTC()},
+		Name:            "Test " + strings.ReplaceAll(typ, "_", " "),
+		ContentType:     "application/json",
+		ContentEncoding: "gzip",
+	}
+	for _, modify := range modifiers {
+		modify(&bulkData)
+	}
+
+	return bulkData
+}
@@ -0,0 +1,290 @@
+package scryfalltest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/BlueMonday/go-scryfall/internal/fsutil"
+)
+
+// ErrUnmatchedRequest is returned by a replaying Recorder for a request which
+// has no unused matching interaction in its cassette.
+var ErrUnmatchedRequest = errors.New("scryfalltest: no recorded interaction matches the request")
+
+// redacted replaces the value of sensitive headers in cassettes.
+const redacted = "REDACTED"
+
+// Mode is the mode of a Recorder.
+type Mode int
+
+const (
+	// ModeReplay serves responses from the cassette file without sending
+	// any requests.
+	ModeReplay Mode = iota
+
+	// ModeRecord sends requests with the underlying transport and records
+	// the interactions to the cassette file when saved.
+	ModeRecord
+)
+
+// Body is a recorded request or response body. It is stored in cassettes as
+// a JSON string if it's UTF-8 text, and otherwise as an object holding the
+// base64 encoded bytes, such as {"base64":"iVBORw0KGgo="}, so binary bodies
+// like card images are replayed unchanged.
+type Body []byte
+
+// MarshalJSON encodes the body as a string or a base64 object.
+func (b Body) MarshalJSON() ([]byte, error) {
+	if utf8.Valid(b) {
+		return json.Marshal(string(b))
+	}
+
+	return json.Marshal(binaryBody{Base64: b})
+}
+
+// UnmarshalJSON decodes a body encoded as a string or a base64 object.
+func (b *Body) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		binary := binaryBody{}
+		err := json.Unmarshal(data, &binary)
+		if err != nil {
+			return err
+		}
+		*b = binary.Base64
+		return nil
+	}
+
+	var text string
+	err := json.Unmarshal(data, &text)
+	if err != nil {
+		return err
+	}
+	*b = Body(text)
+	return nil
+}
+
+// binaryBody is the cassette encoding of a body which isn't UTF-8 text.
+type binaryBody struct {
+	Base64 []byte `json:"base64"`
+}
+
+// RecordedRequest is a request recorded in a cassette.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   Body        `json:"body,omitempty"`
+}
+
+// RecordedResponse is a response recorded in a cassette.
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       Body        `json:"body"`
+}
+
+// Interaction is a request and the response it received.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// Cassette is the recorded interactions stored in a cassette file.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Recorder is an http.RoundTripper which records HTTP interactions to a
+// cassette file and replays them, so tests can use realistic Scryfall
+// responses kept under version control. Pass Client to scryfall.WithHTTPClient
+// to use it. It is safe for concurrent use.
+//
+// Requests are matched by method, URL, and body. Each recorded interaction is
+// replayed at most once, in the order they were recorded, so repeated
+// requests may receive different responses. The Authorization header is
+// redacted from recorded requests.
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// NewRecorder returns a Recorder for the cassette file at path. In
+// ModeReplay the cassette file must exist. In ModeRecord requests are sent
+// with transport, or http.DefaultTransport if it is nil, and the cassette file
+// is only written by Save.
+func NewRecorder(path string, mode Mode, transport http.RoundTripper) (*Recorder, error) {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	r := &Recorder{
+		path:      path,
+		mode:      mode,
+		transport: transport,
+	}
+
+	if mode == ModeReplay {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal(b, &r.cassette)
+		if err != nil {
+			return nil, fmt.Errorf("scryfalltest: decoding cassette %s: %w", path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+
+	return r, nil
+}
+
+// Client returns an HTTP client which uses the recorder as its transport.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip implements http.RoundTripper. The request isn't modified: when
+// recording, a clone of it is sent with the body that was read.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, body)
+	}
+	return r.record(req, body)
+}
+
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		recorded := interaction.Request
+		if r.used[i] || recorded.Method != req.Method || recorded.URL != req.URL.String() || !bytes.Equal(recorded.Body, body) {
+			continue
+		}
+		r.used[i] = true
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        interaction.Response.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s", ErrUnmatchedRequest, req.Method, req.URL)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	outReq := req.Clone(req.Context())
+	if req.Body != nil {
+		outReq.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	resp, err := r.transport.RoundTrip(outReq)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	header := req.Header.Clone()
+	if len(header.Get("Authorization")) != 0 {
+		header.Set("Authorization", redacted)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Header: header,
+			Body:   body,
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header.Clone(),
+			Body:       respBody,
+		},
+	})
+
+	return resp, nil
+}
+
+// Interactions returns the interactions recorded so far, or loaded from the
+// cassette file when replaying.
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	interactions := make([]Interaction, len(r.cassette.Interactions))
+	copy(interactions, r.cassette.Interactions)
+	return interactions
+}
+
+// Unused returns the interactions from the cassette file which haven't been
+// replayed. It always returns nil when recording.
+func (r *Recorder) Unused() []Interaction {
+	if r.mode == ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unused []Interaction
+	for i, interaction := range r.cassette.Interactions {
+		if !r.used[i] {
+			unused = append(unused, interaction)
+		}
+	}
+	return unused
+}
+
+// Save writes the recorded interactions to the cassette file, replacing it
+// atomically so an interrupted save never leaves a truncated cassette. It does
+// nothing when replaying.
+func (r *Recorder) Save() error {
+	if r.mode == ModeReplay {
+		return nil
+	}
+
+	r.mu.Lock()
+	b, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	return fsutil.WriteFileAtomic(r.path, append(b, '\n'), 0o644)
+}
@@ -0,0 +1,152 @@
+package scryfalltest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+)
+
+func cardIDs(cards []scryfall.Card) []string {
+	ids := []string{}
+	for _, card := range cards {
+		ids = append(ids, card.ID)
+	}
+	return ids
+}
+
+func TestRecorder(t *testing.T) {
+	s := NewServer()
+	bolt := NewCard("Lightning Bolt", func(c *scryfall.Card) { c.TypeLine = "Instant" })
+	shock := NewCard("Shock", func(c *scryfall.Card) { c.TypeLine = "Instant" })
+	s.AddCards(bolt, shock, NewCard("Grizzly Bears"))
+
+	ctx := context.Background()
+	identifiers := []scryfall.CardIdentifier{{Name: "Shock"}, {ID: bolt.ID}}
+	exercise := func(client *scryfall.Client) ([]string, []string, error) {
+		result, err := client.SearchCards(ctx, "t:instant", scryfall.SearchCardsOptions{})
+		if err != nil {
+			return nil, nil, err
+		}
+		response, err := client.GetCardsByIdentifiers(ctx, identifiers)
+		if err != nil {
+			return nil, nil, err
+		}
+		return cardIDs(result.Cards), cardIDs(response.Data), nil
+	}
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	recorder, err := NewRecorder(path, ModeRecord, nil)
+	if err != nil {
+		t.Fatalf("Error creating recorder: %v", err)
+	}
+	client, err := s.Client(scryfall.WithHTTPClient(recorder.Client()), scryfall.WithGrantSecret("secret"))
+	if err != nil {
+		t.Fatalf("Error creating client: %v", err)
+	}
+	wantSearch, wantCollection, err := exercise(client)
+	if err != nil {
+		t.Fatalf("Error recording: %v", err)
+	}
+	err = recorder.Save()
+	if err != nil {
+		t.Fatalf("Error saving cassette: %v", err)
+	}
+	s.Close()
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Error reading cassette: %v", err)
+	}
+	if strings.Contains(string(b), "secret") {
+		t.Errorf("cassette contains the Authorization header: %s", b)
+	}
+
+	recorder, err = NewRecorder(path, ModeReplay, nil)
+	if err != nil {
+		t.Fatalf("Error creating recorder: %v", err)
+	}
+	if len(recorder.Interactions()) != 2 {
+		t.Fatalf("got %d interactions want 2", len(recorder.Interactions()))
+	}
+	client, err = scryfall.NewClient(scryfall.WithBaseURL(s.URL), scryfall.WithHTTPClient(recorder.Client()), scryfall.WithLimiter(nil))
+	if err != nil {
+		t.Fatalf("Error creating client: %v", err)
+	}
+	gotSearch, gotCollection, err := exercise(client)
+	if err != nil {
+		t.Fatalf("Error replaying: %v", err)
+	}
+	if !reflect.DeepEqual(gotSearch, wantSearch) {
+		t.Errorf("got: %#v want: %#v", gotSearch, wantSearch)
+	}
+	if !reflect.DeepEqual(gotCollection, wantCollection) {
+		t.Errorf("got: %#v want: %#v", gotCollection, wantCollection)
+	}
+	if unused := recorder.Unused(); len(unused) != 0 {
+		t.Errorf("got %d unused interactions want 0", len(unused))
+	}
+
+	_, err = client.SearchCards(ctx, "t:instant", scryfall.SearchCardsOptions{})
+	if !errors.Is(err, ErrUnmatchedRequest) {
+		t.Errorf("got error %v want ErrUnmatchedRequest", err)
+	}
+}
+
+func TestRecorderBinaryBody(t *testing.T) {
+	s := NewServer()
+	image := []byte{0x89, 'P', 'N', 'G', 0xff, 0x00, 0xfe}
+	s.HandleFunc(http.MethodPost, "/image", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(image)
+	})
+	defer s.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	recorder, err := NewRecorder(path, ModeRecord, nil)
+	if err != nil {
+		t.Fatalf("Error creating recorder: %v", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, s.URL+"/image", bytes.NewReader(image))
+	if err != nil {
+		t.Fatalf("Error creating request: %v", err)
+	}
+	body := req.Body
+	resp, err := recorder.Client().Do(req)
+	if err != nil {
+		t.Fatalf("Error recording: %v", err)
+	}
+	resp.Body.Close()
+	if req.Body != body {
+		t.Errorf("the recorder modified the request body")
+	}
+	err = recorder.Save()
+	if err != nil {
+		t.Fatalf("Error saving cassette: %v", err)
+	}
+
+	recorder, err = NewRecorder(path, ModeReplay, nil)
+	if err != nil {
+		t.Fatalf("Error creating recorder: %v", err)
+	}
+	resp, err = recorder.Client().Post(s.URL+"/image", "image/png", bytes.NewReader(image))
+	if err != nil {
+		t.Fatalf("Error replaying: %v", err)
+	}
+	defer resp.Body.Close()
+	got, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Error reading response: %v", err)
+	}
+	if !bytes.Equal(got, image) {
+		t.Errorf("got body %v want %v", got, image)
+	}
+}
@@ -0,0 +1,365 @@
+// Package scryfalltest provides an in-memory fake Scryfall API server for
+// testing code which uses go-scryfall without network access.
+//
+// A Server answers requests from fixtures added with AddCards, AddSets,
+// AddRulings, and AddBulkData the same way package mirror does, and from
+// responses registered with Handle, HandleFunc, and HandleList. Errors can be
+// scripted with FailNext, and every request is recorded so tests can assert
+// what was requested.
+//
+// A Recorder records real Scryfall interactions to cassette files and replays
+// them, for tests which need realistic responses.
+package scryfalltest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+	"github.com/BlueMonday/go-scryfall/internal/respond"
+	"github.com/BlueMonday/go-scryfall/mirror"
+)
+
+// Request is a request received by a Server.
+type Request struct {
+	// Method is the HTTP method of the request.
+	Method string
+
+	// Path is the URL path of the request.
+	Path string
+
+	// Query holds the query parameters of the request.
+	Query url.Values
+
+	// Header holds the headers of the request.
+	Header http.Header
+
+	// Body is the body of the request.
+	Body []byte
+}
+
+// ErrorResponse is a scripted Scryfall error response.
+type ErrorResponse struct {
+	// Status is the HTTP status code of the response.
+	Status int
+
+	// Code is the Scryfall error code, such as not_found.
+	Code string
+
+	// Details is the human-readable explanation of the error.
+	Details string
+
+	// Type is the optional Scryfall error type, such as ambiguous.
+	Type string
+
+	// RetryAfter is sent as the Retry-After header, in seconds rounded up,
+	// if it's positive.
+	RetryAfter time.Duration
+}
+
+// NotFound returns a 404 not_found error response.
+func NotFound(details string) ErrorResponse {
+	return ErrorResponse{Status: http.StatusNotFound, Code: "not_found", Details: details}
+}
+
+// ValidationError returns a 422 validation_error error response.
+func ValidationError(details string) ErrorResponse {
+	return ErrorResponse{Status: http.StatusUnprocessableEntity, Code: "validation_error", Details: details}
+}
+
+// TooManyRequests returns a 429 rate_limited error response asking the client
+// to retry after the given duration.
+func TooManyRequests(retryAfter time.Duration) ErrorResponse {
+	return ErrorResponse{
+		Status:     http.StatusTooManyRequests,
+		Code:       "rate_limited",
+		Details:    "You are sending requests too quickly. Please slow down.",
+		RetryAfter: retryAfter,
+	}
+}
+
+// Server is a fake Scryfall API server. It is safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	handlers map[string]http.HandlerFunc
+	failures map[string][]ErrorResponse
+	requests []Request
+
+	cards    []scryfall.Card
+	sets     []scryfall.Set
+	rulings  []scryfall.Ruling
+	bulkData []scryfall.BulkData
+	pageSize int
+	fallback http.Handler
+}
+
+// NewServer starts and returns a new Server. The caller should call Close
+// when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		handlers: map[string]http.HandlerFunc{},
+		failures: map[string][]ErrorResponse{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// Client returns a Scryfall client which sends its requests to the server
+// without rate limiting. Additional client options are applied after the
+// server's options.
+func (s *Server) Client(options ...scryfall.ClientOption) (*scryfall.Client, error) {
+	mergedOptions := []scryfall.ClientOption{scryfall.WithBaseURL(s.URL), scryfall.WithLimiter(nil)}
+	mergedOptions = append(mergedOptions, options...)
+
+	return scryfall.NewClient(mergedOptions...)
+}
+
+func routeKey(method, path string) string {
+	return method + " " + path
+}
+
+// Handle registers v to be encoded as the JSON response of requests with the
+// method and exact path, such as "GET" and "/cards/named".
+func (s *Server) Handle(method, path string, v interface{}) {
+	s.HandleFunc(method, path, func(w http.ResponseWriter, r *http.Request) {
+		respond.JSON(w, http.StatusOK, v)
+	})
+}
+
+// HandleFunc registers the handler for requests with the method and exact
+// path. Registered handlers take precedence over fixtures.
+func (s *Server) HandleFunc(method, path string, handler http.HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.handlers[routeKey(method, path)] = handler
+}
+
+var cardType = reflect.TypeOf(scryfall.Card{})
+
+// HandleList registers items, which must be a slice, to be served as a
+// paginated Scryfall list object on GET requests to path. Each page holds up
+// to pageSize items and links to the next page with the page query parameter.
+// Like Scryfall, total_cards is only set on lists of cards.
+func (s *Server) HandleList(path string, items interface{}, pageSize int) {
+	value := reflect.ValueOf(items)
+	if value.Kind() != reflect.Slice {
+		panic(fmt.Sprintf("scryfalltest: HandleList items must be a slice, got %T", items))
+	}
+	if pageSize <= 0 {
+		pageSize = value.Len()
+	}
+
+	s.HandleFunc(http.MethodGet, path, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		page := 1
+		if len(query.Get("page")) != 0 {
+			var err error
+			page, err = strconv.Atoi(query.Get("page"))
+			if err != nil || page < 1 {
+				writeError(w, ErrorResponse{Status: http.StatusBadRequest, Code: "bad_request", Details: "Invalid page."})
+				return
+			}
+		}
+
+		start := (page - 1) * pageSize
+		if start > value.Len() || (start == value.Len() && page > 1) {
+			writeError(w, NotFound("The page you requested is beyond the end of the results."))
+			return
+		}
+		end := start + pageSize
+		if end > value.Len() {
+			end = value.Len()
+		}
+
+		list := respond.List{
+			HasMore: end < value.Len(),
+			Data:    value.Slice(start, end).Interface(),
+		}
+		if value.Type().Elem() == cardType {
+			total := value.Len()
+			list.TotalCards = &total
+		}
+		if list.HasMore {
+			query.Set("page", strconv.Itoa(page+1))
+			nextPage := s.URL + r.URL.Path + "?" + query.Encode()
+			list.NextPage = &nextPage
+		}
+		respond.JSON(w, http.StatusOK, list)
+	})
+}
+
+// FailNext scripts the next request with the method and path to fail with
+// the error response. Scripted errors are used once, in the order they were
+// scripted, and take precedence over handlers and fixtures.
+func (s *Server) FailNext(method, path string, response ErrorResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := routeKey(method, path)
+	s.failures[key] = append(s.failures[key], response)
+}
+
+// AddCards adds card fixtures served by the card endpoints, such as
+// cards/:id, cards/named, cards/search, and cards/collection.
+func (s *Server) AddCards(cards ...scryfall.Card) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cards = append(s.cards, cards...)
+	s.fallback = nil
+}
+
+// AddSets adds set fixtures served by the set endpoints.
+func (s *Server) AddSets(sets ...scryfall.Set) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sets = append(s.sets, sets...)
+	s.fallback = nil
+}
+
+// AddRulings adds ruling fixtures served by the card rulings endpoints. The
+// rulings are matched to cards by oracle ID.
+func (s *Server) AddRulings(rulings ...scryfall.Ruling) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rulings = append(s.rulings, rulings...)
+	s.fallback = nil
+}
+
+// AddBulkData adds bulk data fixtures served by the bulk data endpoints.
+func (s *Server) AddBulkData(bulkData ...scryfall.BulkData) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.bulkData = append(s.bulkData, bulkData...)
+	s.fallback = nil
+}
+
+// SetPageSize sets the number of cards in a page of card search results. The
+// default is 175, like Scryfall.
+func (s *Server) SetPageSize(pageSize int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pageSize = pageSize
+	s.fallback = nil
+}
+
+// Requests returns every request received by the server, in order.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	requests := make([]Request, len(s.requests))
+	copy(requests, s.requests)
+	return requests
+}
+
+// RequestsTo returns the requests received with the method and path.
+func (s *Server) RequestsTo(method, path string) []Request {
+	requests := []Request{}
+	for _, request := range s.Requests() {
+		if request.Method == method && request.Path == path {
+			requests = append(requests, request)
+		}
+	}
+
+	return requests
+}
+
+// AssertRequested reports a test error unless the server received exactly n
+// requests with the method and path.
+func (s *Server) AssertRequested(t testing.TB, method, path string, n int) {
+	t.Helper()
+
+	got := len(s.RequestsTo(method, path))
+	if got != n {
+		t.Errorf("got %d %s requests, want %d", got, routeKey(method, path), n)
+	}
+}
+
+// AssertNotRequested reports a test error if the server received a request
+// with the method and path.
+func (s *Server) AssertNotRequested(t testing.TB, method, path string) {
+	t.Helper()
+
+	s.AssertRequested(t, method, path, 0)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, ErrorResponse{Status: http.StatusBadRequest, Code: "bad_request", Details: err.Error()})
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	key := routeKey(r.Method, r.URL.Path)
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+	var failure *ErrorResponse
+	if failures := s.failures[key]; len(failures) != 0 {
+		failure = &failures[0]
+		s.failures[key] = failures[1:]
+	}
+	handler := s.handlers[key]
+	if s.fallback == nil {
+		s.fallback = mirror.NewServer(mirror.Config{
+			Cards:    scryfall.NewCardStore(s.cards),
+			Sets:     s.sets,
+			Rulings:  scryfall.NewRulingsIndex(s.rulings),
+			BulkData: s.bulkData,
+			PageSize: s.pageSize,
+		})
+	}
+	fallback := s.fallback
+	s.mu.Unlock()
+
+	switch {
+	case failure != nil:
+		writeError(w, *failure)
+	case handler != nil:
+		handler(w, r)
+	default:
+		fallback.ServeHTTP(w, r)
+	}
+}
+
+func writeError(w http.ResponseWriter, response ErrorResponse) {
+	if response.RetryAfter > 0 {
+		seconds := int(math.Ceil(response.RetryAfter.Seconds()))
+		w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	}
+
+	scryfallErr := scryfall.Error{
+		Status:  response.Status,
+		Code:    response.Code,
+		Details: response.Details,
+	}
+	if len(response.Type) != 0 {
+		scryfallErr.Type = &response.Type
+	}
+	respond.Error(w, scryfallErr)
+}
@@ -0,0 +1,200 @@
+package scryfalltest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	scryfall "github.com/BlueMonday/go-scryfall"
+)
+
+func TestServerFixtures(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	bolt := NewCard("Lightning Bolt", func(c *scryfall.Card) {
+		c.TypeLine = "Instant"
+		c.Set = "m10"
+		c.CollectorNumber = "146"
+	})
+	s.AddCards(bolt, NewCard("Shock"))
+	s.AddSets(NewSet("M10"))
+	s.AddRulings(NewRuling(bolt.OracleID, "Any target."))
+	s.AddBulkData(NewBulkData("rulings"))
+
+	client, err := s.Client()
+	if err != nil {
+		t.Fatalf("Error creating client: %v", err)
+	}
+
+	ctx := context.Background()
+	card, err := client.GetCardByName(ctx, "lightning bolt", true, scryfall.GetCardByNameOptions{})
+	if err != nil {
+		t.Fatalf("Error getting card: %v", err)
+	}
+	if card.ID != bolt.ID || !card.ReleasedAt.Equal(bolt.ReleasedAt.Time) {
+		t.Errorf("got: %#v want: %#v", card, bolt)
+	}
+
+	rulings, err := client.GetRulings(ctx, bolt.ID)
+	if err != nil {
+		t.Fatalf("Error getting rulings: %v", err)
+	}
+	if len(rulings) != 1 || rulings[0].Comment != "Any target." {
+		t.Errorf("got rulings: %#v", rulings)
+	}
+
+	set, err := client.GetSet(ctx, "m10")
+	if err != nil {
+		t.Fatalf("Error getting set: %v", err)
+	}
+	if set.Name != "Test Set M10" {
+		t.Errorf("got set name %s want Test Set M10", set.Name)
+	}
+
+	_, err = client.GetBulkDataByType(ctx, "rulings")
+	if err != nil {
+		t.Fatalf("Error getting bulk data: %v", err)
+	}
+
+	s.AssertRequested(t, http.MethodGet, "/cards/named", 1)
+	s.AssertNotRequested(t, http.MethodPost, "/cards/collection")
+	requests := s.RequestsTo(http.MethodGet, "/cards/named")
+	if requests[0].Query.Get("exact") != "lightning bolt" {
+		t.Errorf("got query %v", requests[0].Query)
+	}
+}
+
+func TestServerFailNext(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	card := NewCard("Shock")
+	s.Handle(http.MethodGet, "/cards/random", card)
+	s.FailNext(http.MethodGet, "/cards/random", TooManyRequests(2*time.Second))
+	s.FailNext(http.MethodGet, "/cards/random", ValidationError("Invalid query."))
+
+	client, err := s.Client()
+	if err != nil {
+		t.Fatalf("Error creating client: %v", err)
+	}
+
+	ctx := context.Background()
+	tests := []struct {
+		status int
+		code   string
+	}{
+		{http.StatusTooManyRequests, "rate_limited"},
+		{http.StatusUnprocessableEntity, "validation_error"},
+	}
+	for _, test := range tests {
+		_, err := client.GetRandomCard(ctx)
+		scryfallErr := &scryfall.Error{}
+		if !errors.As(err, &scryfallErr) {
+			t.Fatalf("got error %v, want a Scryfall error", err)
+		}
+		if scryfallErr.Status != test.status || scryfallErr.Code != test.code {
+			t.Errorf("got %d %s want %d %s", scryfallErr.Status, scryfallErr.Code, test.status, test.code)
+		}
+	}
+
+	got, err := client.GetRandomCard(ctx)
+	if err != nil {
+		t.Fatalf("Error getting card: %v", err)
+	}
+	if got.ID != card.ID {
+		t.Errorf("got card %s want %s", got.ID, card.ID)
+	}
+
+	resp, err := http.Get(s.URL + "/missing")
+	if err != nil {
+		t.Fatalf("Error sending request: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("got status %d want 404", resp.StatusCode)
+	}
+
+	s.FailNext(http.MethodGet, "/cards/random", TooManyRequests(2*time.Second))
+	resp, err = http.Get(s.URL + "/cards/random")
+	if err != nil {
+		t.Fatalf("Error sending request: %v", err)
+	}
+	resp.Body.Close()
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "2" {
+		t.Errorf("got Retry-After %q want 2", retryAfter)
+	}
+
+	s.FailNext(http.MethodGet, "/cards/random", TooManyRequests(500*time.Millisecond))
+	resp, err = http.Get(s.URL + "/cards/random")
+	if err != nil {
+		t.Fatalf("Error sending request: %v", err)
+	}
+	resp.Body.Close()
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "1" {
+		t.Errorf("got Retry-After %q want 1", retryAfter)
+	}
+}
+
+func TestServerHandleList(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	migrations := []scryfall.Migration{}
+	for i := 0; i < 5; i++ {
+		migrations = append(migrations, scryfall.Migration{ID: NewID(), MigrationStrategy: scryfall.MigrationStrategyDelete, OldScryfallID: NewID()})
+	}
+	s.HandleList("/migrations", migrations, 2)
+
+	client, err := s.Client()
+	if err != nil {
+		t.Fatalf("Error creating client: %v", err)
+	}
+
+	ctx := context.Background()
+	got, err := client.ListAllMigrations(ctx)
+	if err != nil {
+		t.Fatalf("Error listing migrations: %v", err)
+	}
+	gotIDs := []string{}
+	wantIDs := []string{}
+	for i := range migrations {
+		gotIDs = append(gotIDs, got[i].ID)
+		wantIDs = append(wantIDs, migrations[i].ID)
+	}
+	if !reflect.DeepEqual(gotIDs, wantIDs) {
+		t.Errorf("got: %#v want: %#v", gotIDs, wantIDs)
+	}
+	s.AssertRequested(t, http.MethodGet, "/migrations", 3)
+
+	s.HandleList("/cards/search", []scryfall.Card{NewCard("Lightning Bolt")}, 0)
+	tests := []struct {
+		path       string
+		totalCards bool
+	}{
+		{"/migrations", false},
+		{"/cards/search", true},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			resp, err := http.Get(s.URL + test.path)
+			if err != nil {
+				t.Fatalf("Error getting list: %v", err)
+			}
+			defer resp.Body.Close()
+
+			list := map[string]json.RawMessage{}
+			err = json.NewDecoder(resp.Body).Decode(&list)
+			if err != nil {
+				t.Fatalf("Error decoding list: %v", err)
+			}
+			if _, ok := list["total_cards"]; ok != test.totalCards {
+				t.Errorf("got total_cards %t want %t", ok, test.totalCards)
+			}
+		})
+	}
+}
@@ -3,6 +3,8 @@ package scryfall
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 )
 
 // SetType is a computer-readable classification for a set.
@@ -10,7 +12,7 @@ type SetType string
 
 const (
 	// SetTypeCore is a yearly Magic core set (Tenth Edition, etc).
-	SetTypeCore SetType = "Core"
+	SetTypeCore SetType = "core"
 
 	// SetTypeExpansion is a rotational expansion set in a block (Zendikar,
 	// etc).
@@ -20,10 +22,21 @@ const (
 	// Masters, etc).
 	SetTypeMasters SetType = "masters"
 
+	// SetTypeEternal is a set of new cards only legal in eternal formats
+	// (Jumpstart, etc).
+	SetTypeEternal SetType = "eternal"
+
+	// SetTypeAlchemy is an Arena set designed for Alchemy.
+	SetTypeAlchemy SetType = "alchemy"
+
 	// SetTypeMasterpiece is a set that contains masterpiece series premium
 	// foil cards.
 	SetTypeMasterpiece SetType = "masterpiece"
 
+	// SetTypeArsenal is a collector's edition set outside of normal play
+	// (Commander Collection, etc).
+	SetTypeArsenal SetType = "arsenal"
+
 	// SetTypeFromTheVault is a From the Vault gift set.
 	SetTypeFromTheVault SetType = "from_the_vault"
 
@@ -73,6 +86,9 @@ const (
 	// SetTypeMemorabilia is a set made up of gold-bordered, oversize, or
 	// trophy cards that are not legal.
 	SetTypeMemorabilia SetType = "memorabilia"
+
+	// SetTypeMinigame is a set of minigame cards included in products.
+	SetTypeMinigame SetType = "minigame"
 )
 
 // Set is an object which represents a group of related Magic cards. All Card
@@ -86,14 +102,14 @@ type Set struct {
 
 	// MTGOCode is the unique code for this set on MTGO, which may differ
 	// from the regular code.
-	MTGOCode *string `json:"mtgo_code"`
+	MTGOCode *string `json:"mtgo_code,omitempty"`
 
 	// ArenaCode is the unique code for this set on Magic: The Gathering Arena,
 	// which may differ from the regular code.
-	ArenaCode *string `json:"arena_code"`
+	ArenaCode *string `json:"arena_code,omitempty"`
 
 	// TCGplayerID is the set ID on TCGplayer's API, also known as the groupId.
-	TCGplayerID *int `json:"tcgplayer_id"`
+	TCGplayerID *int `json:"tcgplayer_id,omitempty"`
 
 	// Name is the English name of the set.
 	Name string `json:"name"`
@@ -109,17 +125,17 @@ type Set struct {
 
 	// ReleasedAt is the date the set was released (in GMT-8 Pacific
 	// time). Not all sets have a known release date.
-	ReleasedAt *Date `json:"released_at"`
+	ReleasedAt *Date `json:"released_at,omitempty"`
 
 	// BlockCode is the block code for this set, if any.
-	BlockCode *string `json:"block_code"`
+	BlockCode *string `json:"block_code,omitempty"`
 
 	// Block the block or group name code for this set, if any.
-	Block *string `json:"block"`
+	Block *string `json:"block,omitempty"`
 
 	// ParentSetCode is the set code for the parent set, if any. promo and
 	// token sets often have a parent set.
-	ParentSetCode string `json:"parent_set_code"`
+	ParentSetCode string `json:"parent_set_code,omitempty"`
 
 	// CardCount is the number of cards in this set.
 	CardCount int `json:"card_count"`
@@ -139,6 +155,15 @@ type Set struct {
 	// SearchURI is a Scryfall API URI that you can request to begin
 	// paginating over the cards in this set.
 	SearchURI string `json:"search_uri"`
+
+	extraFields
+}
+
+// MarshalJSON encodes the set in Scryfall's format, including its
+// UnknownFields.
+func (s Set) MarshalJSON() ([]byte, error) {
+	type set Set
+	return encodeWithUnknownFields((*set)(&s), "set", s.UnknownFields())
 }
 
 // ListSets lists all of the sets on Scryfall.
@@ -152,14 +177,319 @@ func (c *Client) ListSets(ctx context.Context) ([]Set, error) {
 	return sets, nil
 }
 
-// GetSet returns a set with the given set code.
-func (c *Client) GetSet(ctx context.Context, code string) (Set, error) {
-	setURL := fmt.Sprintf("sets/%s", code)
+func (c *Client) getSet(ctx context.Context, url string) (Set, error) {
 	set := Set{}
-	err := c.get(ctx, setURL, &set)
+	err := c.get(ctx, url, &set)
 	if err != nil {
 		return Set{}, err
 	}
 
 	return set, nil
 }
+
+// GetSet returns a set with the given set code.
+func (c *Client) GetSet(ctx context.Context, code string) (Set, error) {
+	setURL := fmt.Sprintf("sets/%s", code)
+	return c.getSet(ctx, setURL)
+}
+
+// GetSetByID returns a set with the given Scryfall ID.
+func (c *Client) GetSetByID(ctx context.Context, id string) (Set, error) {
+	setURL := fmt.Sprintf("sets/%s", id)
+	return c.getSet(ctx, setURL)
+}
+
+// GetSetByTCGPlayerID returns a set with the given TCGplayer ID, also known as
+// the groupId on TCGplayer's API.
+func (c *Client) GetSetByTCGPlayerID(ctx context.Context, tcgPlayerID int) (Set, error) {
+	setURL := fmt.Sprintf("sets/tcgplayer/%d", tcgPlayerID)
+	return c.getSet(ctx, setURL)
+}
+
+// SetIndex resolves sets by any of their identifiers without making API
+// requests. Build one from the result of ListSets.
+type SetIndex struct {
+	sets          []Set
+	byCode        map[string]int
+	byID          map[string]int
+	byMTGOCode    map[string]int
+	byArenaCode   map[string]int
+	byTCGPlayerID map[int]int
+}
+
+// NewSetIndex returns an index of the given sets. Codes are matched
+// case-insensitively.
+func NewSetIndex(sets []Set) *SetIndex {
+	idx := &SetIndex{
+		sets:          sets,
+		byCode:        map[string]int{},
+		byID:          map[string]int{},
+		byMTGOCode:    map[string]int{},
+		byArenaCode:   map[string]int{},
+		byTCGPlayerID: map[int]int{},
+	}
+	for i, set := range sets {
+		idx.byCode[strings.ToLower(set.Code)] = i
+		if len(set.ID) != 0 {
+			idx.byID[set.ID] = i
+		}
+		if set.MTGOCode != nil {
+			idx.byMTGOCode[strings.ToLower(*set.MTGOCode)] = i
+		}
+		if set.ArenaCode != nil {
+			idx.byArenaCode[strings.ToLower(*set.ArenaCode)] = i
+		}
+		if set.TCGplayerID != nil {
+			idx.byTCGPlayerID[*set.TCGplayerID] = i
+		}
+	}
+
+	return idx
+}
+
+// Sets returns every set in the index. The returned slice must not be
+// modified.
+func (idx *SetIndex) Sets() []Set {
+	return idx.sets
+}
+
+func (idx *SetIndex) lookup(index map[string]int, key string) (Set, bool) {
+	i, ok := index[key]
+	if !ok {
+		return Set{}, false
+	}
+
+	return idx.sets[i], true
+}
+
+// ByCode returns the set with the given set code.
+func (idx *SetIndex) ByCode(code string) (Set, bool) {
+	return idx.lookup(idx.byCode, strings.ToLower(code))
+}
+
+// ByID returns the set with the given Scryfall ID.
+func (idx *SetIndex) ByID(id string) (Set, bool) {
+	return idx.lookup(idx.byID, id)
+}
+
+// ByMTGOCode returns the set with the given Magic Online code.
+func (idx *SetIndex) ByMTGOCode(code string) (Set, bool) {
+	return idx.lookup(idx.byMTGOCode, strings.ToLower(code))
+}
+
+// ByArenaCode returns the set with the given Magic: The Gathering Arena code.
+func (idx *SetIndex) ByArenaCode(code string) (Set, bool) {
+	return idx.lookup(idx.byArenaCode, strings.ToLower(code))
+}
+
+// ByTCGPlayerID returns the set with the given TCGplayer ID.
+func (idx *SetIndex) ByTCGPlayerID(tcgPlayerID int) (Set, bool) {
+	i, ok := idx.byTCGPlayerID[tcgPlayerID]
+	if !ok {
+		return Set{}, false
+	}
+
+	return idx.sets[i], true
+}
+
+// Resolve returns the set identified by s, which may be a Scryfall ID, a set
+// code, an MTGO code or an Arena code, checked in that order.
+func (idx *SetIndex) Resolve(s string) (Set, bool) {
+	if set, ok := idx.ByID(s); ok {
+		return set, true
+	}
+	if set, ok := idx.ByCode(s); ok {
+		return set, true
+	}
+	if set, ok := idx.ByMTGOCode(s); ok {
+		return set, true
+	}
+	return idx.ByArenaCode(s)
+}
+
+// SetTree answers questions about how sets relate to each other, such as
+// which sets are the promos and tokens of an expansion, without making API
+// requests. Build one from the result of ListSets. Sets are ordered by
+// ReleasedAt, oldest first, and sets without a release date come last.
+type SetTree struct {
+	sets     []Set
+	byCode   map[string]int
+	children map[string][]int
+	blocks   map[string][]int
+}
+
+// NewSetTree returns a tree of the given sets. Codes are matched
+// case-insensitively.
+func NewSetTree(sets []Set) *SetTree {
+	sorted := make([]Set, len(sets))
+	copy(sorted, sets)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return releasedBefore(sorted[i], sorted[j])
+	})
+
+	tree := &SetTree{
+		sets:     sorted,
+		byCode:   map[string]int{},
+		children: map[string][]int{},
+		blocks:   map[string][]int{},
+	}
+	for i, set := range sorted {
+		tree.byCode[strings.ToLower(set.Code)] = i
+		if len(set.ParentSetCode) != 0 {
+			parentCode := strings.ToLower(set.ParentSetCode)
+			tree.children[parentCode] = append(tree.children[parentCode], i)
+		}
+		if set.BlockCode != nil {
+			blockCode := strings.ToLower(*set.BlockCode)
+			tree.blocks[blockCode] = append(tree.blocks[blockCode], i)
+		}
+	}
+
+	return tree
+}
+
+// releasedBefore reports whether a was released before b. Sets without a
+// release date are released after every other set.
+func releasedBefore(a, b Set) bool {
+	if a.ReleasedAt == nil {
+		return false
+	}
+	if b.ReleasedAt == nil {
+		return true
+	}
+	return a.ReleasedAt.Before(b.ReleasedAt.Time)
+}
+
+func (tree *SetTree) collect(indexes []int) []Set {
+	sets := make([]Set, 0, len(indexes))
+	for _, i := range indexes {
+		sets = append(sets, tree.sets[i])
+	}
+	return sets
+}
+
+// Sets returns every set in the tree in release order. The returned slice
+// must not be modified.
+func (tree *SetTree) Sets() []Set {
+	return tree.sets
+}
+
+// Set returns the set with the given set code.
+func (tree *SetTree) Set(code string) (Set, bool) {
+	i, ok := tree.byCode[strings.ToLower(code)]
+	if !ok {
+		return Set{}, false
+	}
+
+	return tree.sets[i], true
+}
+
+// Parent returns the parent of the set with the given set code. It returns
+// false if the set doesn't have a parent, or if its parent isn't in the tree.
+func (tree *SetTree) Parent(code string) (Set, bool) {
+	set, ok := tree.Set(code)
+	if !ok || len(set.ParentSetCode) == 0 {
+		return Set{}, false
+	}
+
+	return tree.Set(set.ParentSetCode)
+}
+
+// Root returns the topmost ancestor of the set with the given set code that
+// is in the tree, which is the set itself if it doesn't have a parent.
+func (tree *SetTree) Root(code string) (Set, bool) {
+	set, ok := tree.Set(code)
+	if !ok {
+		return Set{}, false
+	}
+
+	// Bound the walk by the number of sets in case the parents form a
+	// cycle.
+	for i := 0; i < len(tree.sets); i++ {
+		parent, ok := tree.Parent(set.Code)
+		if !ok {
+			break
+		}
+		set = parent
+	}
+	return set, true
+}
+
+// Children returns the sets whose parent is the set with the given set code,
+// in release order.
+func (tree *SetTree) Children(code string) []Set {
+	return tree.collect(tree.children[strings.ToLower(code)])
+}
+
+// Siblings returns the other sets sharing the parent of the set with the
+// given set code, in release order. Sets without a parent don't have
+// siblings.
+func (tree *SetTree) Siblings(code string) []Set {
+	set, ok := tree.Set(code)
+	if !ok || len(set.ParentSetCode) == 0 {
+		return []Set{}
+	}
+
+	siblings := []Set{}
+	for _, sibling := range tree.Children(set.ParentSetCode) {
+		if !strings.EqualFold(sibling.Code, set.Code) {
+			siblings = append(siblings, sibling)
+		}
+	}
+	return siblings
+}
+
+// Block returns the sets in the block with the given block code, in release
+// order.
+func (tree *SetTree) Block(blockCode string) []Set {
+	return tree.collect(tree.blocks[strings.ToLower(blockCode)])
+}
+
+// SetFilter holds the options used to filter the sets of a SetTree.
+type SetFilter struct {
+	// SetTypes limits the results to sets of the given types. All set
+	// types are included by default.
+	SetTypes []SetType
+
+	// Digital limits the results to digital sets if true, or paper sets if
+	// false. Both are included by default.
+	Digital *bool
+
+	// FoilOnly limits the results to foil only sets if true, or sets with
+	// nonfoil cards if false. Both are included by default.
+	FoilOnly *bool
+}
+
+func (f SetFilter) matches(set Set) bool {
+	if f.Digital != nil && set.Digital != *f.Digital {
+		return false
+	}
+	if f.FoilOnly != nil && set.FoilOnly != *f.FoilOnly {
+		return false
+	}
+	if len(f.SetTypes) != 0 {
+		found := false
+		for _, setType := range f.SetTypes {
+			if set.SetType == setType {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Filter returns the sets matching the filter, in release order.
+func (tree *SetTree) Filter(f SetFilter) []Set {
+	sets := []Set{}
+	for _, set := range tree.sets {
+		if f.matches(set) {
+			sets = append(sets, set)
+		}
+	}
+	return sets
+}
@@ -2,6 +2,7 @@ package scryfall
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -138,3 +139,167 @@ func TestGetSet(t *testing.T) {
 		t.Errorf("got: %#v want: %#v", set, want)
 	}
 }
+
+func TestGetSetByID(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"object": "set", "id": "2ec77b94-6d47-4891-a480-5d0b4e5c9372", "code": "uma", "name": "Ultimate Masters"}`)
+	})
+	client, ts, err := setupTestServer("/sets/2ec77b94-6d47-4891-a480-5d0b4e5c9372", handler)
+	if err != nil {
+		t.Fatalf("Error setting up test server: %v", err)
+	}
+	defer ts.Close()
+
+	ctx := context.Background()
+	set, err := client.GetSetByID(ctx, "2ec77b94-6d47-4891-a480-5d0b4e5c9372")
+	if err != nil {
+		t.Fatalf("Error getting set: %v", err)
+	}
+
+	want := Set{ID: "2ec77b94-6d47-4891-a480-5d0b4e5c9372", Code: "uma", Name: "Ultimate Masters"}
+	if !reflect.DeepEqual(set, want) {
+		t.Errorf("got: %#v want: %#v", set, want)
+	}
+}
+
+func TestGetSetByTCGPlayerID(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"object": "set", "code": "dom", "tcgplayer_id": 2199, "name": "Dominaria"}`)
+	})
+	client, ts, err := setupTestServer("/sets/tcgplayer/2199", handler)
+	if err != nil {
+		t.Fatalf("Error setting up test server: %v", err)
+	}
+	defer ts.Close()
+
+	ctx := context.Background()
+	set, err := client.GetSetByTCGPlayerID(ctx, 2199)
+	if err != nil {
+		t.Fatalf("Error getting set: %v", err)
+	}
+
+	want := Set{Code: "dom", TCGplayerID: intPointer(2199), Name: "Dominaria"}
+	if !reflect.DeepEqual(set, want) {
+		t.Errorf("got: %#v want: %#v", set, want)
+	}
+}
+
+func TestSetTypeValues(t *testing.T) {
+	tests := []struct {
+		in  string
+		out SetType
+	}{
+		{"core", SetTypeCore},
+		{"eternal", SetTypeEternal},
+		{"alchemy", SetTypeAlchemy},
+		{"arsenal", SetTypeArsenal},
+		{"minigame", SetTypeMinigame},
+	}
+
+	for _, test := range tests {
+		t.Run(test.in, func(t *testing.T) {
+			set := Set{}
+			err := json.Unmarshal([]byte(fmt.Sprintf(`{"object": "set", "set_type": %q}`, test.in)), &set)
+			if err != nil {
+				t.Fatalf("Error decoding set: %v", err)
+			}
+			if set.SetType != test.out {
+				t.Errorf("got: %q want: %q", set.SetType, test.out)
+			}
+		})
+	}
+}
+
+func TestSetIndex(t *testing.T) {
+	idx := NewSetIndex([]Set{
+		{ID: "dom-id", Code: "dom", MTGOCode: stringPointer("dar"), ArenaCode: stringPointer("dar"), TCGplayerID: intPointer(2199)},
+		{ID: "con-id", Code: "con", MTGOCode: stringPointer("cfx"), ArenaCode: nil},
+		{ID: "eld-id", Code: "eld", MTGOCode: stringPointer("eld"), ArenaCode: stringPointer("eld")},
+	})
+
+	tests := []struct {
+		name   string
+		lookup func() (Set, bool)
+		code   string
+	}{
+		{"code", func() (Set, bool) { return idx.ByCode("DOM") }, "dom"},
+		{"id", func() (Set, bool) { return idx.ByID("con-id") }, "con"},
+		{"mtgo code", func() (Set, bool) { return idx.ByMTGOCode("cfx") }, "con"},
+		{"arena code", func() (Set, bool) { return idx.ByArenaCode("DAR") }, "dom"},
+		{"tcgplayer id", func() (Set, bool) { return idx.ByTCGPlayerID(2199) }, "dom"},
+		{"resolve id", func() (Set, bool) { return idx.Resolve("eld-id") }, "eld"},
+		{"resolve mtgo code", func() (Set, bool) { return idx.Resolve("cfx") }, "con"},
+		{"missing", func() (Set, bool) { return idx.Resolve("zzz") }, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			set, ok := test.lookup()
+			if ok != (len(test.code) != 0) || set.Code != test.code {
+				t.Errorf("got: %q, %v want: %q", set.Code, ok, test.code)
+			}
+		})
+	}
+}
+
+func TestSetTree(t *testing.T) {
+	released := func(year int, month time.Month, day int) *Date {
+		return &Date{Time: time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+	}
+	tree := NewSetTree([]Set{
+		{Code: "tdom", SetType: SetTypeToken, ParentSetCode: "dom", ReleasedAt: released(2018, 4, 27)},
+		{Code: "dom", SetType: SetTypeExpansion, ReleasedAt: released(2018, 4, 27)},
+		{Code: "pdom", SetType: SetTypePromo, ParentSetCode: "dom", ReleasedAt: released(2018, 4, 7), FoilOnly: true},
+		{Code: "xln", SetType: SetTypeExpansion, BlockCode: stringPointer("xln"), ReleasedAt: released(2017, 9, 29)},
+		{Code: "rix", SetType: SetTypeExpansion, BlockCode: stringPointer("xln"), ReleasedAt: released(2018, 1, 19)},
+		{Code: "prix", SetType: SetTypePromo, ParentSetCode: "RIX", ReleasedAt: released(2018, 1, 19), FoilOnly: true},
+		{Code: "ydmu", SetType: SetTypeAlchemy, Digital: true, ReleasedAt: released(2022, 9, 8)},
+		{Code: "zzz", SetType: SetTypeMemorabilia},
+	})
+
+	codes := func(sets []Set) []string {
+		codes := []string{}
+		for _, set := range sets {
+			codes = append(codes, set.Code)
+		}
+		return codes
+	}
+	set := func(f func(string) (Set, bool), code string) []Set {
+		set, ok := f(code)
+		if !ok {
+			return nil
+		}
+		return []Set{set}
+	}
+
+	tests := []struct {
+		name string
+		sets []Set
+		want []string
+	}{
+		{"sets", tree.Sets(), []string{"xln", "rix", "prix", "pdom", "tdom", "dom", "ydmu", "zzz"}},
+		{"parent", set(tree.Parent, "TDOM"), []string{"dom"}},
+		{"parent mixed case", set(tree.Parent, "prix"), []string{"rix"}},
+		{"no parent", set(tree.Parent, "dom"), []string{}},
+		{"missing parent", set(tree.Parent, "zzz"), []string{}},
+		{"root", set(tree.Root, "pdom"), []string{"dom"}},
+		{"root of root", set(tree.Root, "dom"), []string{"dom"}},
+		{"children", tree.Children("dom"), []string{"pdom", "tdom"}},
+		{"no children", tree.Children("xln"), []string{}},
+		{"siblings", tree.Siblings("tdom"), []string{"pdom"}},
+		{"no siblings", tree.Siblings("dom"), []string{}},
+		{"block", tree.Block("XLN"), []string{"xln", "rix"}},
+		{"filter set types", tree.Filter(SetFilter{SetTypes: []SetType{SetTypePromo, SetTypeAlchemy}}), []string{"prix", "pdom", "ydmu"}},
+		{"filter digital", tree.Filter(SetFilter{Digital: boolPointer(true)}), []string{"ydmu"}},
+		{"filter foil only", tree.Filter(SetFilter{SetTypes: []SetType{SetTypePromo, SetTypeToken}, FoilOnly: boolPointer(false)}), []string{"tdom"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := codes(test.sets)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got: %v want: %v", got, test.want)
+			}
+		})
+	}
+}
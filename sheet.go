@@ -0,0 +1,385 @@
+package scryfall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"strconv"
+)
+
+// CardImageFetcher fetches card images. Client fetches images from Scryfall
+// and ImageCache serves them from disk.
+type CardImageFetcher interface {
+	GetCardImage(ctx context.Context, card Card, version ImageVersion, face int) (CardImage, error)
+}
+
+// GetCardImage is like Get, and allows an ImageCache to be used as a
+// CardImageFetcher.
+func (c *ImageCache) GetCardImage(ctx context.Context, card Card, version ImageVersion, face int) (CardImage, error) {
+	return c.Get(ctx, card, version, face)
+}
+
+// DeckEntry is a card and the number of copies of it in a deck.
+type DeckEntry struct {
+	// Card is the card.
+	Card Card
+
+	// Quantity is the number of copies of the card.
+	Quantity int
+}
+
+// SheetFormat is an image format a sheet can be encoded in.
+type SheetFormat string
+
+const (
+	// SheetFormatPNG encodes sheets as PNG images.
+	SheetFormatPNG SheetFormat = "png"
+
+	// SheetFormatJPEG encodes sheets as JPEG images.
+	SheetFormatJPEG SheetFormat = "jpeg"
+)
+
+// EncodeSheet writes the sheet to w in the given format.
+func EncodeSheet(w io.Writer, sheet image.Image, format SheetFormat) error {
+	switch format {
+	case SheetFormatPNG:
+		return png.Encode(w, sheet)
+	case SheetFormatJPEG:
+		return jpeg.Encode(w, sheet, &jpeg.Options{Quality: 90})
+	}
+
+	return fmt.Errorf("unknown sheet format %q", format)
+}
+
+// ContactSheetOptions holds the options used to render a contact sheet.
+type ContactSheetOptions struct {
+	// Version is the image version used for each card. The default version
+	// is ImageVersionSmall.
+	Version ImageVersion
+
+	// Columns is the number of cards in each row. The default is 5.
+	Columns int
+
+	// Spacing is the space between cards and around the edge of the sheet,
+	// in pixels.
+	Spacing int
+
+	// Background is the color of the sheet behind the cards. The default
+	// background is white.
+	Background color.Color
+
+	// QuantityBadges draws the quantity of each card in its top right
+	// corner when the deck contains more than one copy.
+	QuantityBadges bool
+}
+
+// RenderContactSheet renders the front face of every card in the deck into a
+// grid, for example to preview a deck. Each card is drawn once regardless of
+// its quantity. Every image is scaled to the size of the first card image.
+func RenderContactSheet(ctx context.Context, fetcher CardImageFetcher, deck []DeckEntry, opts ContactSheetOptions) (image.Image, error) {
+	if len(deck) == 0 {
+		return nil, errors.New("empty deck")
+	}
+	version := opts.Version
+	if len(version) == 0 {
+		version = ImageVersionSmall
+	}
+	columns := opts.Columns
+	if columns <= 0 {
+		columns = 5
+	}
+	if columns > len(deck) {
+		columns = len(deck)
+	}
+	background := opts.Background
+	if background == nil {
+		background = color.White
+	}
+
+	var sheet *image.RGBA
+	var cardSize image.Point
+	for i, entry := range deck {
+		img, err := fetchCardImage(ctx, fetcher, entry.Card, version, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		if sheet == nil {
+			cardSize = img.Bounds().Size()
+			rows := (len(deck) + columns - 1) / columns
+			width := columns*cardSize.X + (columns+1)*opts.Spacing
+			height := rows*cardSize.Y + (rows+1)*opts.Spacing
+			sheet = image.NewRGBA(image.Rect(0, 0, width, height))
+			draw.Draw(sheet, sheet.Bounds(), image.NewUniform(background), image.Point{}, draw.Src)
+		}
+
+		x := opts.Spacing + (i%columns)*(cardSize.X+opts.Spacing)
+		y := opts.Spacing + (i/columns)*(cardSize.Y+opts.Spacing)
+		cell := image.Rect(x, y, x+cardSize.X, y+cardSize.Y)
+		drawScaled(sheet, cell, img)
+
+		if opts.QuantityBadges && entry.Quantity > 1 {
+			drawQuantityBadge(sheet, cell, entry.Quantity)
+		}
+	}
+
+	return sheet, nil
+}
+
+// PageSize is the size of a printed page, in inches.
+type PageSize struct {
+	Width  float64
+	Height float64
+}
+
+var (
+	// PageSizeLetter is a US Letter page.
+	PageSizeLetter = PageSize{Width: 8.5, Height: 11}
+
+	// PageSizeA4 is an ISO A4 page.
+	PageSizeA4 = PageSize{Width: 210 / 25.4, Height: 297 / 25.4}
+)
+
+const (
+	// cardWidthInches and cardHeightInches are the dimensions of a Magic
+	// card, 63 by 88 millimeters.
+	cardWidthInches  = 63 / 25.4
+	cardHeightInches = 88 / 25.4
+)
+
+// ProxySheetOptions holds the options used to render proxy sheets.
+type ProxySheetOptions struct {
+	// Version is the image version used for each card. The default version
+	// is ImageVersionLarge.
+	Version ImageVersion
+
+	// DPI is the resolution the sheets are rendered at. The default is 300.
+	DPI int
+
+	// PageSize is the size of the printed page. The default is
+	// PageSizeLetter.
+	PageSize PageSize
+
+	// BackFaces includes a proxy for the back face of double-sided cards.
+	BackFaces bool
+}
+
+// RenderProxySheets renders print-ready proxy sheets for the deck. Each sheet
+// holds a 3x3 grid of cards at their real size, centered on the page with cut
+// marks in the margins. Cards are repeated according to their quantity.
+func RenderProxySheets(ctx context.Context, fetcher CardImageFetcher, deck []DeckEntry, opts ProxySheetOptions) ([]image.Image, error) {
+	version := opts.Version
+	if len(version) == 0 {
+		version = ImageVersionLarge
+	}
+	dpi := opts.DPI
+	if dpi <= 0 {
+		dpi = 300
+	}
+	pageSize := opts.PageSize
+	if pageSize == (PageSize{}) {
+		pageSize = PageSizeLetter
+	}
+
+	cardWidth := int(cardWidthInches*float64(dpi) + 0.5)
+	cardHeight := int(cardHeightInches*float64(dpi) + 0.5)
+	pageWidth := int(pageSize.Width*float64(dpi) + 0.5)
+	pageHeight := int(pageSize.Height*float64(dpi) + 0.5)
+	if pageWidth < 3*cardWidth || pageHeight < 3*cardHeight {
+		return nil, errors.New("page is too small for a 3x3 grid of cards")
+	}
+	left := (pageWidth - 3*cardWidth) / 2
+	top := (pageHeight - 3*cardHeight) / 2
+
+	sheets := []image.Image{}
+	var sheet *image.RGBA
+	slot := 0
+	for _, entry := range deck {
+		faces := []int{0}
+		if opts.BackFaces {
+			if _, ok := entry.Card.Back(); ok {
+				faces = append(faces, 1)
+			}
+		}
+
+		for _, face := range faces {
+			img, err := fetchCardImage(ctx, fetcher, entry.Card, version, face)
+			if errors.Is(err, ErrImageUnavailable) && face > 0 {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			for n := 0; n < entry.Quantity; n++ {
+				if sheet == nil {
+					sheet = image.NewRGBA(image.Rect(0, 0, pageWidth, pageHeight))
+					draw.Draw(sheet, sheet.Bounds(), image.White, image.Point{}, draw.Src)
+					drawCutMarks(sheet, left, top, cardWidth, cardHeight, dpi)
+					sheets = append(sheets, sheet)
+				}
+
+				x := left + (slot%3)*cardWidth
+				y := top + (slot/3)*cardHeight
+				drawScaled(sheet, image.Rect(x, y, x+cardWidth, y+cardHeight), img)
+
+				slot++
+				if slot == 9 {
+					sheet = nil
+					slot = 0
+				}
+			}
+		}
+	}
+
+	return sheets, nil
+}
+
+func fetchCardImage(ctx context.Context, fetcher CardImageFetcher, card Card, version ImageVersion, face int) (image.Image, error) {
+	cardImage, err := fetcher.GetCardImage(ctx, card, version, face)
+	if err != nil {
+		return nil, err
+	}
+
+	img, err := cardImage.Decode()
+	if err != nil {
+		return nil, fmt.Errorf("decoding image of %s: %w", card.Name, err)
+	}
+
+	return img, nil
+}
+
+// drawCutMarks draws short lines in the page margins lining up with the edges
+// of every card in the grid.
+func drawCutMarks(dst *image.RGBA, left, top, cardWidth, cardHeight, dpi int) {
+	length := dpi / 8
+	black := image.NewUniform(color.Black)
+	right := left + 3*cardWidth
+	bottom := top + 3*cardHeight
+
+	for i := 0; i <= 3; i++ {
+		x := left + i*cardWidth
+		draw.Draw(dst, image.Rect(x, top-length, x+1, top), black, image.Point{}, draw.Src)
+		draw.Draw(dst, image.Rect(x, bottom, x+1, bottom+length), black, image.Point{}, draw.Src)
+
+		y := top + i*cardHeight
+		draw.Draw(dst, image.Rect(left-length, y, left, y+1), black, image.Point{}, draw.Src)
+		draw.Draw(dst, image.Rect(right, y, right+length, y+1), black, image.Point{}, draw.Src)
+	}
+}
+
+// drawScaled draws src scaled to fill the rectangle r of dst using bilinear
+// interpolation.
+func drawScaled(dst *image.RGBA, r image.Rectangle, src image.Image) {
+	sb := src.Bounds()
+	if sb.Size() == r.Size() {
+		draw.Draw(dst, r, src, sb.Min, draw.Over)
+		return
+	}
+
+	scaleX := float64(sb.Dx()) / float64(r.Dx())
+	scaleY := float64(sb.Dy()) / float64(r.Dy())
+	for y := 0; y < r.Dy(); y++ {
+		fy := (float64(y)+0.5)*scaleY - 0.5
+		y0, wy := clampedFloor(fy, sb.Dy())
+		y1, _ := clampedFloor(fy+1, sb.Dy())
+		for x := 0; x < r.Dx(); x++ {
+			fx := (float64(x)+0.5)*scaleX - 0.5
+			x0, wx := clampedFloor(fx, sb.Dx())
+			x1, _ := clampedFloor(fx+1, sb.Dx())
+
+			c00 := color.RGBA64Model.Convert(src.At(sb.Min.X+x0, sb.Min.Y+y0)).(color.RGBA64)
+			c10 := color.RGBA64Model.Convert(src.At(sb.Min.X+x1, sb.Min.Y+y0)).(color.RGBA64)
+			c01 := color.RGBA64Model.Convert(src.At(sb.Min.X+x0, sb.Min.Y+y1)).(color.RGBA64)
+			c11 := color.RGBA64Model.Convert(src.At(sb.Min.X+x1, sb.Min.Y+y1)).(color.RGBA64)
+			lerp := func(a, b, c, d uint16) uint16 {
+				top := float64(a)*(1-wx) + float64(b)*wx
+				bottom := float64(c)*(1-wx) + float64(d)*wx
+				return uint16(top*(1-wy) + bottom*wy + 0.5)
+			}
+			pixel := color.RGBA64{
+				R: lerp(c00.R, c10.R, c01.R, c11.R),
+				G: lerp(c00.G, c10.G, c01.G, c11.G),
+				B: lerp(c00.B, c10.B, c01.B, c11.B),
+				A: lerp(c00.A, c10.A, c01.A, c11.A),
+			}
+			// Composite the premultiplied pixel over the background so
+			// the rounded corners of PNG images stay transparent.
+			under := dst.RGBA64At(r.Min.X+x, r.Min.Y+y)
+			inv := 1 - float64(pixel.A)/0xffff
+			dst.SetRGBA64(r.Min.X+x, r.Min.Y+y, color.RGBA64{
+				R: pixel.R + uint16(float64(under.R)*inv),
+				G: pixel.G + uint16(float64(under.G)*inv),
+				B: pixel.B + uint16(float64(under.B)*inv),
+				A: pixel.A + uint16(float64(under.A)*inv),
+			})
+		}
+	}
+}
+
+// clampedFloor returns the integer part of f clamped to [0, n) and the
+// fractional weight of the next pixel.
+func clampedFloor(f float64, n int) (int, float64) {
+	if f <= 0 {
+		return 0, 0
+	}
+	i := int(f)
+	if i >= n-1 {
+		return n - 1, 0
+	}
+	return i, f - float64(i)
+}
+
+// digitGlyphs is a 3x5 pixel font for the digits 0-9, one row per string.
+var digitGlyphs = [10][5]string{
+	{"###", "#.#", "#.#", "#.#", "###"},
+	{".#.", "##.", ".#.", ".#.", "###"},
+	{"###", "..#", "###", "#..", "###"},
+	{"###", "..#", "###", "..#", "###"},
+	{"#.#", "#.#", "###", "..#", "..#"},
+	{"###", "#..", "###", "..#", "###"},
+	{"###", "#..", "###", "#.#", "###"},
+	{"###", "..#", "..#", "..#", "..#"},
+	{"###", "#.#", "###", "#.#", "###"},
+	{"###", "#.#", "###", "..#", "###"},
+}
+
+// drawQuantityBadge draws the quantity in white on a black box in the top
+// right corner of the cell. The standard library has no text rendering, so
+// the digits are drawn with a tiny built-in pixel font.
+func drawQuantityBadge(dst *image.RGBA, cell image.Rectangle, quantity int) {
+	digits := strconv.Itoa(quantity)
+	scale := cell.Dx() / 40
+	if scale < 1 {
+		scale = 1
+	}
+	padding := scale * 2
+	width := len(digits)*4*scale - scale + 2*padding
+	height := 5*scale + 2*padding
+
+	box := image.Rect(cell.Max.X-width-padding, cell.Min.Y+padding, cell.Max.X-padding, cell.Min.Y+padding+height)
+	draw.Draw(dst, box, image.NewUniform(color.RGBA{A: 220}), image.Point{}, draw.Over)
+
+	white := image.NewUniform(color.White)
+	for i, digit := range digits {
+		glyph := digitGlyphs[digit-'0']
+		originX := box.Min.X + padding + i*4*scale
+		originY := box.Min.Y + padding
+		for row, line := range glyph {
+			for col, pixel := range line {
+				if pixel != '#' {
+					continue
+				}
+				x := originX + col*scale
+				y := originY + row*scale
+				draw.Draw(dst, image.Rect(x, y, x+scale, y+scale), white, image.Point{}, draw.Src)
+			}
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package scryfall
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"testing"
+)
+
+type fakeImageFetcher struct {
+	data   []byte
+	images int
+}
+
+func (f *fakeImageFetcher) GetCardImage(ctx context.Context, card Card, version ImageVersion, face int) (CardImage, error) {
+	if face > 0 {
+		if _, ok := card.Back(); !ok {
+			return CardImage{}, ErrImageUnavailable
+		}
+	}
+	f.images++
+	return CardImage{Version: version, Face: face, ContentType: "image/png", Data: f.data}, nil
+}
+
+func TestRenderContactSheet(t *testing.T) {
+	fetcher := &fakeImageFetcher{data: testPNG(t, 40, 56)}
+	deck := []DeckEntry{
+		{Card: Card{Name: "Lightning Bolt"}, Quantity: 4},
+		{Card: Card{Name: "Mountain"}, Quantity: 16},
+		{Card: Card{Name: "Goblin Guide"}, Quantity: 1},
+	}
+
+	ctx := context.Background()
+	sheet, err := RenderContactSheet(ctx, fetcher, deck, ContactSheetOptions{Columns: 2, Spacing: 5, QuantityBadges: true})
+	if err != nil {
+		t.Fatalf("Error rendering contact sheet: %v", err)
+	}
+
+	want := image.Rect(0, 0, 2*40+3*5, 2*56+3*5)
+	if sheet.Bounds() != want {
+		t.Errorf("got bounds: %v want: %v", sheet.Bounds(), want)
+	}
+	if fetcher.images != 3 {
+		t.Errorf("got %d images fetched, want 3", fetcher.images)
+	}
+
+	// The test image is blue with a red top left pixel.
+	r, g, b, _ := sheet.At(5, 5).RGBA()
+	if r != 0xffff || g != 0 || b != 0 {
+		t.Errorf("got color %v at card origin, want red", sheet.At(5, 5))
+	}
+
+	// The last cell is empty and shows the background.
+	if got := color.RGBAModel.Convert(sheet.At(want.Max.X-10, want.Max.Y-10)); got != (color.RGBA{255, 255, 255, 255}) {
+		t.Errorf("got color %v in empty cell, want white", got)
+	}
+
+	buf := &bytes.Buffer{}
+	err = EncodeSheet(buf, sheet, SheetFormatJPEG)
+	if err != nil {
+		t.Fatalf("Error encoding contact sheet: %v", err)
+	}
+}
+
+func TestRenderProxySheets(t *testing.T) {
+	fetcher := &fakeImageFetcher{data: testPNG(t, 63, 88)}
+	deck := []DeckEntry{
+		{Card: Card{Name: "Lightning Bolt"}, Quantity: 4},
+		{Card: delverOfSecrets, Quantity: 4},
+		{Card: Card{Name: "Mountain"}, Quantity: 2},
+	}
+
+	ctx := context.Background()
+	sheets, err := RenderProxySheets(ctx, fetcher, deck, ProxySheetOptions{DPI: 100, BackFaces: true})
+	if err != nil {
+		t.Fatalf("Error rendering proxy sheets: %v", err)
+	}
+
+	// 4 bolts, 4 delver fronts, 4 delver backs and 2 mountains.
+	if len(sheets) != 2 {
+		t.Fatalf("got %d sheets, want 2", len(sheets))
+	}
+	want := image.Rect(0, 0, 850, 1100)
+	if sheets[0].Bounds() != want {
+		t.Errorf("got bounds: %v want: %v", sheets[0].Bounds(), want)
+	}
+
+	cardWidth := 248
+	cardHeight := 346
+	left := (850 - 3*cardWidth) / 2
+	top := (1100 - 3*cardHeight) / 2
+	if got := color.GrayModel.Convert(sheets[1].At(left+cardWidth, top-2)); got != (color.Gray{}) {
+		t.Errorf("got color %v for cut mark, want black", got)
+	}
+	if got := color.RGBAModel.Convert(sheets[1].At(left+cardWidth+cardWidth/2, top+cardHeight+cardHeight/2)); got != (color.RGBA{B: 255, A: 255}) {
+		t.Errorf("got color %v for filled slot, want blue", got)
+	}
+	if got := color.GrayModel.Convert(sheets[1].At(left+2*cardWidth+cardWidth/2, top+cardHeight+cardHeight/2)); got != (color.Gray{Y: 255}) {
+		t.Errorf("got color %v for empty slot, want white", got)
+	}
+
+	_, err = RenderProxySheets(ctx, fetcher, deck, ProxySheetOptions{PageSize: PageSize{Width: 5, Height: 5}})
+	if err == nil {
+		t.Errorf("expected error rendering proxy sheets on a small page")
+	}
+}
@@ -0,0 +1,130 @@
+package scryfall
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// CardStore is an in-memory collection of cards, usually loaded from one of
+// Scryfall's card bulk data files. A CardStore answers lookups locally so they
+// don't cost an API request.
+//
+// A CardStore must not be modified after it is created, but it is safe for
+// concurrent use.
+type CardStore struct {
+	cards      []Card
+	byID       map[string]int
+	byOracleID map[string][]int
+}
+
+// NewCardStore returns a CardStore containing the given cards.
+func NewCardStore(cards []Card) *CardStore {
+	s := &CardStore{
+		cards:      cards,
+		byID:       make(map[string]int, len(cards)),
+		byOracleID: make(map[string][]int),
+	}
+	for i, card := range cards {
+		s.byID[card.ID] = i
+		for _, oracleID := range cardOracleIDs(card) {
+			s.byOracleID[oracleID] = append(s.byOracleID[oracleID], i)
+		}
+	}
+
+	return s
+}
+
+// LoadCardStore decodes a JSON array of cards, such as a Scryfall card bulk
+// data file, and returns a CardStore containing them. The cards are decoded
+// one at a time so the raw file is never held in memory.
+func LoadCardStore(r io.Reader) (*CardStore, error) {
+	cards := []Card{}
+	err := decodeJSONArray(r, "cards", func(decoder *json.Decoder) error {
+		card := Card{}
+		err := decoder.Decode(&card)
+		if err != nil {
+			return err
+		}
+		cards = append(cards, card)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCardStore(cards), nil
+}
+
+// decodeJSONArray streams the elements of a JSON array of the given kind,
+// calling decode once for each element with the decoder positioned on it.
+func decodeJSONArray(r io.Reader, kind string, decode func(decoder *json.Decoder) error) error {
+	decoder := json.NewDecoder(r)
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("expected JSON array of %s, got %v", kind, token)
+	}
+
+	for decoder.More() {
+		err := decode(decoder)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = decoder.Token()
+	return err
+}
+
+// cardOracleIDs returns the oracle IDs of a card. Reversible cards don't have
+// a top-level oracle ID, instead each face has its own.
+func cardOracleIDs(card Card) []string {
+	if len(card.OracleID) != 0 {
+		return []string{card.OracleID}
+	}
+
+	oracleIDs := []string{}
+	for _, face := range card.CardFaces {
+		if face.OracleID != nil && len(*face.OracleID) != 0 {
+			oracleIDs = append(oracleIDs, *face.OracleID)
+		}
+	}
+	return oracleIDs
+}
+
+// Len returns the number of cards in the store.
+func (s *CardStore) Len() int {
+	return len(s.cards)
+}
+
+// Cards returns every card in the store. The returned slice must not be
+// modified.
+func (s *CardStore) Cards() []Card {
+	return s.cards
+}
+
+// Card returns the card with the given Scryfall ID.
+func (s *CardStore) Card(id string) (Card, bool) {
+	i, ok := s.byID[id]
+	if !ok {
+		return Card{}, false
+	}
+
+	return s.cards[i], true
+}
+
+// PrintingsByOracleID returns every printing in the store of the card with the
+// given oracle ID.
+func (s *CardStore) PrintingsByOracleID(ctx context.Context, oracleID string) ([]Card, error) {
+	indexes := s.byOracleID[oracleID]
+	printings := make([]Card, 0, len(indexes))
+	for _, i := range indexes {
+		printings = append(printings, s.cards[i])
+	}
+
+	return printings, nil
+}
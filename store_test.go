@@ -0,0 +1,63 @@
+package scryfall
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadCardStore(t *testing.T) {
+	store, err := LoadCardStore(strings.NewReader("[" + duskDawnJSON + "]"))
+	if err != nil {
+		t.Fatalf("Error loading card store: %v", err)
+	}
+
+	if store.Len() != 1 {
+		t.Fatalf("got %d cards, want 1", store.Len())
+	}
+
+	card, ok := store.Card("937dbc51-b589-4237-9fce-ea5c757f7c48")
+	if !ok {
+		t.Fatalf("card not found in store")
+	}
+	if !reflect.DeepEqual(card, duskDawn) {
+		t.Errorf("got: %#v want: %#v", card, duskDawn)
+	}
+
+	_, ok = store.Card("nope")
+	if ok {
+		t.Errorf("unexpected card found in store")
+	}
+}
+
+func TestLoadCardStoreNotArray(t *testing.T) {
+	_, err := LoadCardStore(strings.NewReader(duskDawnJSON))
+	if err == nil {
+		t.Fatalf("expected error loading card store from a single card")
+	}
+}
+
+func TestCardStorePrintingsByOracleID(t *testing.T) {
+	store := NewCardStore([]Card{
+		{ID: "a", OracleID: "x"},
+		{ID: "b", OracleID: "y"},
+		{ID: "c", OracleID: "x"},
+		{ID: "d", CardFaces: []CardFace{{OracleID: stringPointer("x")}, {OracleID: stringPointer("z")}}},
+	})
+
+	ctx := context.Background()
+	printings, err := store.PrintingsByOracleID(ctx, "x")
+	if err != nil {
+		t.Fatalf("Error getting printings: %v", err)
+	}
+
+	ids := []string{}
+	for _, printing := range printings {
+		ids = append(ids, printing.ID)
+	}
+	want := []string{"a", "c", "d"}
+	if !reflect.DeepEqual(ids, want) {
+		t.Errorf("got: %v want: %v", ids, want)
+	}
+}
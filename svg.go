@@ -0,0 +1,349 @@
+package scryfall
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/BlueMonday/go-scryfall/internal/fsutil"
+)
+
+// ErrSVGUnavailable is returned when a set or card symbol doesn't have an SVG
+// asset.
+var ErrSVGUnavailable = errors.New("SVG unavailable")
+
+// SVGAssets downloads and caches the SVG assets of set icons and card
+// symbols, so they can be served locally instead of hotlinking Scryfall's CDN.
+// Assets are kept in memory and, if a directory is configured, on disk. An
+// asset is downloaded again when its URI changes.
+//
+// SVGAssets is safe for concurrent use.
+type SVGAssets struct {
+	client *Client
+	dir    string
+
+	mu       sync.Mutex
+	setIcons map[string]svgAsset
+	symbols  map[string]svgAsset
+}
+
+type svgAsset struct {
+	uri  string
+	data []byte
+}
+
+// NewSVGAssets returns an SVG asset cache which downloads assets with client.
+// If dir isn't empty, assets are also stored in dir so they survive restarts.
+func NewSVGAssets(client *Client, dir string) (*SVGAssets, error) {
+	if len(dir) != 0 {
+		for _, subdir := range []string{"sets", "symbols"} {
+			err := os.MkdirAll(filepath.Join(dir, subdir), 0o755)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	a := &SVGAssets{
+		client:   client,
+		dir:      dir,
+		setIcons: map[string]svgAsset{},
+		symbols:  map[string]svgAsset{},
+	}
+	return a, nil
+}
+
+// SetIcon returns the SVG icon of the set, downloading it if needed.
+func (a *SVGAssets) SetIcon(ctx context.Context, set Set) ([]byte, error) {
+	if len(set.IconSVGURI) == 0 {
+		return nil, ErrSVGUnavailable
+	}
+
+	return a.get(ctx, a.setIcons, "sets", set.Code, set.IconSVGURI)
+}
+
+// Symbol returns the SVG image of the card symbol, downloading it if needed.
+func (a *SVGAssets) Symbol(ctx context.Context, symbol CardSymbol) ([]byte, error) {
+	if symbol.SVGURI == nil || len(*symbol.SVGURI) == 0 {
+		return nil, ErrSVGUnavailable
+	}
+
+	return a.get(ctx, a.symbols, "symbols", symbol.Symbol, *symbol.SVGURI)
+}
+
+// LoadSetIcons downloads the icons of every set that has one.
+func (a *SVGAssets) LoadSetIcons(ctx context.Context, sets []Set) error {
+	for _, set := range sets {
+		_, err := a.SetIcon(ctx, set)
+		if err != nil && !errors.Is(err, ErrSVGUnavailable) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// LoadSymbols downloads the images of every card symbol that has one, for
+// example the symbols returned by ListCardSymbols.
+func (a *SVGAssets) LoadSymbols(ctx context.Context, symbols []CardSymbol) error {
+	for _, symbol := range symbols {
+		_, err := a.Symbol(ctx, symbol)
+		if err != nil && !errors.Is(err, ErrSVGUnavailable) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetIconByCode returns the cached icon of the set with the given code.
+func (a *SVGAssets) SetIconByCode(code string) ([]byte, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	asset, ok := a.setIcons[code]
+	return asset.data, ok
+}
+
+// SymbolByText returns the cached image of the card symbol with the given
+// plaintext, such as {W} or {2/U}.
+func (a *SVGAssets) SymbolByText(symbol string) ([]byte, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	asset, ok := a.symbols[symbol]
+	return asset.data, ok
+}
+
+func (a *SVGAssets) get(ctx context.Context, assets map[string]svgAsset, kind, key, uri string) ([]byte, error) {
+	a.mu.Lock()
+	asset, ok := assets[key]
+	a.mu.Unlock()
+	if ok && asset.uri == uri {
+		return asset.data, nil
+	}
+
+	var path string
+	if len(a.dir) != 0 {
+		name := url.PathEscape(key)
+		if !isSafeFileName(name) {
+			return nil, fmt.Errorf("invalid SVG asset key %q", key)
+		}
+		path = filepath.Join(a.dir, kind, name)
+		storedURI, err := os.ReadFile(path + ".uri")
+		if err == nil && string(storedURI) == uri {
+			data, err := os.ReadFile(path + ".svg")
+			if err == nil {
+				a.mu.Lock()
+				assets[key] = svgAsset{uri: uri, data: data}
+				a.mu.Unlock()
+				return data, nil
+			}
+		}
+	}
+
+	data, _, err := a.client.download(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(path) != 0 {
+		err = fsutil.WriteFileAtomic(path+".svg", data, 0o644)
+		if err != nil {
+			return nil, err
+		}
+		err = fsutil.WriteFileAtomic(path+".uri", []byte(uri), 0o644)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	a.mu.Lock()
+	assets[key] = svgAsset{uri: uri, data: data}
+	a.mu.Unlock()
+	return data, nil
+}
+
+// isSafeFileName reports whether name can be used as the name of a file in a
+// cache directory without escaping it.
+func isSafeFileName(name string) bool {
+	if len(name) == 0 || name == "." || name == ".." {
+		return false
+	}
+
+	return !strings.ContainsAny(name, "/\\\x00")
+}
+
+const (
+	svgNamespace   = "http://www.w3.org/2000/svg"
+	xlinkNamespace = "http://www.w3.org/1999/xlink"
+)
+
+// svgElements are the SVG elements kept by InlineSVG. Everything else,
+// including script, style, foreignObject, image and a, is removed along with
+// its content.
+var svgElements = map[string]bool{
+	"svg": true, "g": true, "defs": true, "symbol": true, "use": true,
+	"title": true, "desc": true, "path": true, "rect": true, "circle": true,
+	"ellipse": true, "line": true, "polyline": true, "polygon": true,
+	"text": true, "tspan": true, "linearGradient": true,
+	"radialGradient": true, "stop": true, "clipPath": true, "mask": true,
+}
+
+// svgAttributes are the presentation and geometry attributes kept by
+// InlineSVG. Event handlers, style and external references are removed.
+var svgAttributes = map[string]bool{
+	"id": true, "class": true, "version": true, "viewBox": true,
+	"preserveAspectRatio": true, "width": true, "height": true, "x": true,
+	"y": true, "x1": true, "y1": true, "x2": true, "y2": true, "cx": true,
+	"cy": true, "r": true, "rx": true, "ry": true, "fx": true, "fy": true,
+	"d": true, "points": true, "transform": true, "opacity": true,
+	"fill": true, "fill-opacity": true, "fill-rule": true, "clip-rule": true,
+	"clip-path": true, "mask": true, "stroke": true, "stroke-width": true,
+	"stroke-linecap": true, "stroke-linejoin": true,
+	"stroke-miterlimit": true, "stroke-dasharray": true,
+	"stroke-dashoffset": true, "stroke-opacity": true, "offset": true,
+	"stop-color": true, "stop-opacity": true, "gradientUnits": true,
+	"gradientTransform": true, "clipPathUnits": true, "maskUnits": true,
+	"font-family": true, "font-size": true, "font-weight": true,
+	"text-anchor": true,
+}
+
+var (
+	svgExternalURLRegexp = regexp.MustCompile(`(?i)url\(\s*['"]?\s*[^#'"\s]`)
+	svgSymbolRegexp      = regexp.MustCompile(`\{[^{}]+\}`)
+)
+
+// sanitizeSVGAttr returns the name and value an attribute of an SVG element
+// is written with, or false if it must be removed. Only local references
+// such as href="#a" or fill="url(#b)" are kept.
+func sanitizeSVGAttr(attr xml.Attr) (string, string, bool) {
+	if attr.Name.Local == "href" && (attr.Name.Space == "" || attr.Name.Space == xlinkNamespace || attr.Name.Space == "xlink") {
+		if !strings.HasPrefix(attr.Value, "#") {
+			return "", "", false
+		}
+		return "href", attr.Value, true
+	}
+	if len(attr.Name.Space) != 0 || !svgAttributes[attr.Name.Local] {
+		return "", "", false
+	}
+	if svgExternalURLRegexp.MatchString(attr.Value) {
+		return "", "", false
+	}
+
+	return attr.Name.Local, attr.Value, true
+}
+
+// InlineSVG prepares an SVG document for embedding directly in an HTML page,
+// adding class to the class attribute of the root element if class isn't
+// empty.
+//
+// Since the document is written into the page as is, it is rebuilt from an
+// allowlist of SVG elements and attributes: scripts, event handlers, styles,
+// external references and anything outside the SVG namespace are removed.
+// InlineSVG returns an empty string if the document isn't well-formed XML or
+// its root element isn't svg. Use SVGDataURI to display an SVG document
+// unchanged.
+func InlineSVG(svg []byte, class string) template.HTML {
+	var b strings.Builder
+	d := xml.NewDecoder(bytes.NewReader(svg))
+	depth := 0
+	skip := 0
+	for {
+		token, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return ""
+		}
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			if depth == 0 && (token.Name.Local != "svg" || b.Len() != 0) {
+				return ""
+			}
+			depth++
+			if skip != 0 || !svgElements[token.Name.Local] || (token.Name.Space != "" && token.Name.Space != svgNamespace) {
+				skip++
+				continue
+			}
+
+			b.WriteString("<" + token.Name.Local)
+			if depth == 1 {
+				b.WriteString(` xmlns="` + svgNamespace + `"`)
+			}
+			classWritten := false
+			for _, attr := range token.Attr {
+				name, value, ok := sanitizeSVGAttr(attr)
+				if !ok {
+					continue
+				}
+				if depth == 1 && name == "class" && len(class) != 0 {
+					value = strings.TrimSpace(value + " " + class)
+					classWritten = true
+				}
+				b.WriteString(" " + name + `="` + template.HTMLEscapeString(value) + `"`)
+			}
+			if depth == 1 && !classWritten && len(class) != 0 {
+				b.WriteString(` class="` + template.HTMLEscapeString(class) + `"`)
+			}
+			b.WriteString(">")
+		case xml.EndElement:
+			depth--
+			if skip != 0 {
+				skip--
+				continue
+			}
+			b.WriteString("</" + token.Name.Local + ">")
+		case xml.CharData:
+			if depth != 0 && skip == 0 {
+				b.WriteString(template.HTMLEscapeString(string(token)))
+			}
+		}
+	}
+
+	return template.HTML(b.String())
+}
+
+// SVGDataURI returns a data URI containing the SVG document, which can be used
+// as the src of an img element.
+func SVGDataURI(svg []byte) template.URL {
+	return template.URL("data:image/svg+xml;base64," + base64.StdEncoding.EncodeToString(svg))
+}
+
+// RenderSymbols returns the text as HTML with every cached card symbol, such
+// as the {2}{W} of a mana cost or the {T} of Oracle text, replaced by its
+// sanitized inline SVG image with the given class, see InlineSVG. Symbols
+// which aren't cached or can't be inlined are left as escaped text.
+func (a *SVGAssets) RenderSymbols(text string, class string) template.HTML {
+	var b strings.Builder
+	last := 0
+	for _, loc := range svgSymbolRegexp.FindAllStringIndex(text, -1) {
+		svg, ok := a.SymbolByText(text[loc[0]:loc[1]])
+		if !ok {
+			continue
+		}
+		inline := InlineSVG(svg, class)
+		if len(inline) == 0 {
+			continue
+		}
+		b.WriteString(template.HTMLEscapeString(text[last:loc[0]]))
+		b.WriteString(string(inline))
+		last = loc[1]
+	}
+	b.WriteString(template.HTMLEscapeString(text[last:]))
+
+	return template.HTML(b.String())
+}
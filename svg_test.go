@@ -0,0 +1,179 @@
+package scryfall
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSVGAssets(t *testing.T) {
+	requests := 0
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "image/svg+xml")
+		fmt.Fprintf(w, `<?xml version="1.0" encoding="utf-8"?>
+<!DOCTYPE svg PUBLIC "-//W3C//DTD SVG 1.1//EN" "http://www.w3.org/Graphics/SVG/1.1/DTD/svg11.dtd">
+<svg xmlns="http://www.w3.org/2000/svg" id="%s"></svg>`, r.URL.Path)
+	})
+	client, ts, err := setupTestServer("/", handler)
+	if err != nil {
+		t.Fatalf("Error setting up test server: %v", err)
+	}
+	defer ts.Close()
+
+	dir := t.TempDir()
+	assets, err := NewSVGAssets(client, dir)
+	if err != nil {
+		t.Fatalf("Error creating SVG assets: %v", err)
+	}
+
+	ctx := context.Background()
+	sets := []Set{
+		{Code: "dom", IconSVGURI: ts.URL + "/sets/dom.svg?1"},
+		{Code: "a25", IconSVGURI: ts.URL + "/sets/a25.svg?1"},
+		{Code: "none"},
+	}
+	err = assets.LoadSetIcons(ctx, sets)
+	if err != nil {
+		t.Fatalf("Error loading set icons: %v", err)
+	}
+	symbols := []CardSymbol{
+		{Symbol: "{W}", SVGURI: stringPointer(ts.URL + "/card-symbols/W.svg")},
+		{Symbol: "{2/U}", SVGURI: stringPointer(ts.URL + "/card-symbols/2U.svg")},
+	}
+	err = assets.LoadSymbols(ctx, symbols)
+	if err != nil {
+		t.Fatalf("Error loading symbols: %v", err)
+	}
+	if requests != 4 {
+		t.Errorf("got %d requests, want 4", requests)
+	}
+
+	if _, ok := assets.SetIconByCode("dom"); !ok {
+		t.Errorf("set icon not cached")
+	}
+	if _, ok := assets.SetIconByCode("none"); ok {
+		t.Errorf("unexpected icon for set without icon")
+	}
+	for _, ext := range []string{".svg", ".uri"} {
+		path := filepath.Join(dir, "sets", "dom"+ext)
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("Error reading file mode: %v", err)
+		}
+		if mode := info.Mode().Perm(); mode != 0o644 {
+			t.Errorf("got mode %v for %s want %v", mode, path, os.FileMode(0o644))
+		}
+	}
+
+	got := assets.RenderSymbols("{2/U}{W}: Draw a <card>. {T}", "ms")
+	want := `<svg xmlns="http://www.w3.org/2000/svg" id="/card-symbols/2U.svg" class="ms"></svg><svg xmlns="http://www.w3.org/2000/svg" id="/card-symbols/W.svg" class="ms"></svg>: Draw a &lt;card&gt;. {T}`
+	if string(got) != want {
+		t.Errorf("got: %s want: %s", got, want)
+	}
+
+	// A new cache using the same directory shouldn't download anything
+	// unless an asset's URI changes.
+	assets, err = NewSVGAssets(client, dir)
+	if err != nil {
+		t.Fatalf("Error creating SVG assets: %v", err)
+	}
+	sets[1].IconSVGURI = ts.URL + "/sets/a25.svg?2"
+	err = assets.LoadSetIcons(ctx, sets)
+	if err != nil {
+		t.Fatalf("Error loading set icons: %v", err)
+	}
+	if requests != 5 {
+		t.Errorf("got %d requests, want 5", requests)
+	}
+}
+
+func TestSVGAssetsInvalidKey(t *testing.T) {
+	client, err := NewClient()
+	if err != nil {
+		t.Fatalf("Error creating client: %v", err)
+	}
+	assets, err := NewSVGAssets(client, t.TempDir())
+	if err != nil {
+		t.Fatalf("Error creating SVG assets: %v", err)
+	}
+
+	_, err = assets.SetIcon(context.Background(), Set{Code: "..", IconSVGURI: "https://svgs.scryfall.io/sets/dom.svg"})
+	if err == nil {
+		t.Errorf("expected an error for an invalid set code")
+	}
+}
+
+func TestInlineSVG(t *testing.T) {
+	tests := []struct {
+		name  string
+		svg   string
+		class string
+		want  string
+	}{
+		{
+			"prolog",
+			`<?xml version="1.0"?><!DOCTYPE svg><svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 10 10"><path d="M0 0h10v10z" fill="#fff"/></svg>`,
+			"",
+			`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 10 10"><path d="M0 0h10v10z" fill="#fff"></path></svg>`,
+		},
+		{
+			"merge class",
+			`<svg xmlns="http://www.w3.org/2000/svg" class="icon"></svg>`,
+			"ms ms-w",
+			`<svg xmlns="http://www.w3.org/2000/svg" class="icon ms ms-w"></svg>`,
+		},
+		{
+			"escape class",
+			`<svg xmlns="http://www.w3.org/2000/svg"></svg>`,
+			`"><script>`,
+			`<svg xmlns="http://www.w3.org/2000/svg" class="&#34;&gt;&lt;script&gt;"></svg>`,
+		},
+		{
+			"scripts and handlers",
+			`<svg xmlns="http://www.w3.org/2000/svg" onload="alert(1)"><script>alert(2)</script><g onclick="alert(3)" style="x"><circle r="1"/></g><foreignObject><div>x</div></foreignObject></svg>`,
+			"",
+			`<svg xmlns="http://www.w3.org/2000/svg"><g><circle r="1"></circle></g></svg>`,
+		},
+		{
+			"references",
+			`<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink"><a href="javascript:alert(1)"><path d="M0 0"/></a><use xlink:href="#p"/><use href="https://example.com/x.svg#p"/><rect fill="url(#g)"/><rect fill="url(https://example.com/x)"/><image href="https://example.com/x.png"/></svg>`,
+			"",
+			`<svg xmlns="http://www.w3.org/2000/svg"><use href="#p"></use><use></use><rect fill="url(#g)"></rect><rect></rect></svg>`,
+		},
+		{
+			"other namespace",
+			`<svg xmlns="http://www.w3.org/2000/svg" xmlns:h="http://www.w3.org/1999/xhtml"><h:path d="M0 0"/></svg>`,
+			"",
+			`<svg xmlns="http://www.w3.org/2000/svg"></svg>`,
+		},
+		{
+			"text",
+			`<svg xmlns="http://www.w3.org/2000/svg"><title>a &lt;b&gt;</title></svg>`,
+			"",
+			`<svg xmlns="http://www.w3.org/2000/svg"><title>a &lt;b&gt;</title></svg>`,
+		},
+		{"not svg", `<html><svg></svg></html>`, "", ``},
+		{"malformed", `<svg><path></svg>`, "", ``},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := InlineSVG([]byte(test.svg), test.class)
+			if string(got) != test.want {
+				t.Errorf("got: %s want: %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSVGDataURI(t *testing.T) {
+	got := SVGDataURI([]byte("<svg></svg>"))
+	want := "data:image/svg+xml;base64,PHN2Zz48L3N2Zz4="
+	if string(got) != want {
+		t.Errorf("got: %s want: %s", got, want)
+	}
+}
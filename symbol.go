@@ -76,6 +76,15 @@ type CardSymbol struct {
 
 	// SVGURI is a URI to an SVG image of this symbol on Scryfall’s CDNs.
 	SVGURI *string `json:"svg_uri"`
+
+	extraFields
+}
+
+// MarshalJSON encodes the card symbol in Scryfall's format, including its
+// UnknownFields.
+func (s CardSymbol) MarshalJSON() ([]byte, error) {
+	type cardSymbol CardSymbol
+	return encodeWithUnknownFields((*cardSymbol)(&s), "", s.UnknownFields())
 }
 
 // ManaCost is Scryfall's interpretation of a mana cost.